@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"gmdb/config"
+	"gmdb/models"
+	"gmdb/repository"
+	"gmdb/services"
+	"gmdb/telemetry"
+
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+var (
+	apiKeyName      string
+	apiKeyScopes    []string
+	apiKeyExpiresIn time.Duration
+)
+
+var apiKeyCmd = &cobra.Command{
+	Use:   "apikey",
+	Short: "Manage API keys for machine clients",
+	Long: "Creates, lists and revokes the keys machine clients send in the X-API-Key header. " +
+		"Each key holds explicit scopes such as movies:read or awards:write.",
+}
+
+var apiKeyCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create an API key and print it once",
+	Long: "Creates an API key with the given scopes. The key is printed once and cannot be recovered, e.g.\n" +
+		"  gmdb apikey create --name importer --scope movies:read --scope movies:write\n\n" +
+		"Scopes: " + joinScopes(models.Scopes),
+	Run: func(cmd *cobra.Command, args []string) {
+		req := services.CreateAPIKeyRequest{Name: apiKeyName}
+		for _, scope := range apiKeyScopes {
+			req.Scopes = append(req.Scopes, models.Scope(scope))
+		}
+		if apiKeyExpiresIn > 0 {
+			expiresAt := time.Now().Add(apiKeyExpiresIn)
+			req.ExpiresAt = &expiresAt
+		}
+
+		key, err := newAPIKeyService().CreateAPIKey(context.Background(), req)
+		if err != nil {
+			printErrorDetails(err)
+			log.Fatal("Failed to create API key:", err)
+		}
+
+		fmt.Fprintf(os.Stderr, "Created API key %s (%s) with scopes %s, expires %s\n",
+			key.Prefix, key.Name, joinScopes(key.Scopes), formatTime(key.ExpiresAt, "never"))
+		fmt.Fprintln(os.Stderr, "Store it now, it is not shown again:")
+		fmt.Println(key.Key)
+	},
+}
+
+var apiKeyListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List API keys",
+	Run: func(cmd *cobra.Command, args []string) {
+		keys, err := newAPIKeyService().ListAPIKeys(context.Background())
+		if err != nil {
+			log.Fatal("Failed to list API keys:", err)
+		}
+
+		now := time.Now()
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "PREFIX\tNAME\tSCOPES\tEXPIRES\tLAST USED\tSTATUS")
+		for _, key := range keys {
+			status := "active"
+			switch {
+			case key.RevokedAt != nil:
+				status = "revoked"
+			case key.ExpiresAt != nil && !now.Before(*key.ExpiresAt):
+				status = "expired"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", key.Prefix, key.Name, joinScopes(key.Scopes),
+				formatTime(key.ExpiresAt, "never"), formatTime(key.LastUsedAt, "never"), status)
+		}
+		_ = w.Flush()
+	},
+}
+
+var apiKeyRevokeCmd = &cobra.Command{
+	Use:   "revoke <id|prefix>",
+	Short: "Revoke an API key by ID or prefix",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := newAPIKeyService().RevokeAPIKey(context.Background(), args[0]); err != nil {
+			log.Fatal("Failed to revoke API key:", err)
+		}
+		fmt.Printf("Revoked API key %s\n", args[0])
+	},
+}
+
+// newAPIKeyService connects to the configured database for the apikey commands
+func newAPIKeyService() *services.APIKeyService {
+	cfg := mustLoadConfig(config.SectionDatabase)
+	db, err := config.ConnectDB(cfg.Database)
+	if err != nil {
+		log.Fatal("Failed to connect to database:", err)
+	}
+	requireCurrentSchema(db)
+
+	repos := repository.NewGormRepositories(db)
+	return services.NewAPIKeyService(repos.APIKeys, noop.NewTracerProvider().Tracer(telemetry.InstrumentationName))
+}
+
+// printErrorDetails lists the field errors of a validation failure on stderr
+func printErrorDetails(err error) {
+	var domainErr *services.Error
+	if errors.As(err, &domainErr) {
+		for _, detail := range domainErr.Details {
+			fmt.Fprintf(os.Stderr, "  %s: %s\n", detail.Field, detail.Message)
+		}
+	}
+}
+
+func joinScopes[S ~[]models.Scope](scopes S) string {
+	parts := make([]string, len(scopes))
+	for i, scope := range scopes {
+		parts[i] = string(scope)
+	}
+	return strings.Join(parts, " ")
+}
+
+func formatTime(t *time.Time, zero string) string {
+	if t == nil {
+		return zero
+	}
+	return t.Local().Format(time.DateTime)
+}
+
+func init() {
+	apiKeyCreateCmd.Flags().StringVar(&apiKeyName, "name", "", "what the key is for, e.g. the client using it")
+	apiKeyCreateCmd.Flags().StringArrayVar(&apiKeyScopes, "scope", nil, "scope to grant; repeat for several")
+	apiKeyCreateCmd.Flags().DurationVar(&apiKeyExpiresIn, "expires-in", 90*24*time.Hour, "lifetime of the key; 0 never expires")
+	_ = apiKeyCreateCmd.MarkFlagRequired("name")
+	_ = apiKeyCreateCmd.MarkFlagRequired("scope")
+
+	apiKeyCmd.AddCommand(apiKeyCreateCmd, apiKeyListCmd, apiKeyRevokeCmd)
+}
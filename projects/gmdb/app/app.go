@@ -0,0 +1,222 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"sync/atomic"
+
+	"gmdb/auth"
+	"gmdb/config"
+	"gmdb/handlers"
+	"gmdb/logging"
+	"gmdb/metrics"
+	"gmdb/middleware"
+	"gmdb/migrations"
+	"gmdb/ratelimit"
+	"gmdb/repository"
+	"gmdb/routes"
+	"gmdb/services"
+	"gmdb/telemetry"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"gorm.io/gorm"
+)
+
+// App owns everything one gmdb server instance needs. Nothing is shared
+// through package globals, so several instances can live in one process.
+type App struct {
+	Config   *config.Config // startup settings; read reloadable ones from Live
+	Live     *config.Store  // current config including runtime reloads
+	LogLevel slog.LevelVar  // follows app.log_level
+	Logger   *slog.Logger   // structured logger in app.log_format
+	DB       *gorm.DB       // nil when running on in-memory repositories
+	Repos    *repository.Repositories
+	Services *services.Services
+	Handlers *handlers.Handlers
+	Router   *gin.Engine
+	Metrics  *metrics.Metrics
+	Tracing  trace.TracerProvider // no-op unless WithTracerProvider is given
+	Tokens   *auth.Tokens         // issues and verifies access tokens
+	// RateLimits holds client allowances; in-process unless WithRateLimitStore is given
+	RateLimits ratelimit.Store
+
+	mu       sync.Mutex
+	hooks    []shutdownHook
+	draining atomic.Bool // set once shutdown starts so /readyz turns traffic away
+}
+
+// Option customises an App before its components are built
+type Option func(*App)
+
+// WithTracerProvider traces requests, service methods and queries with tp
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(a *App) {
+		a.Tracing = tp
+	}
+}
+
+// WithRateLimitStore keeps rate limit allowances in store, e.g. one shared by
+// every replica
+func WithRateLimitStore(store ratelimit.Store) Option {
+	return func(a *App) {
+		a.RateLimits = store
+	}
+}
+
+// New creates an application backed by the PostgreSQL database db
+func New(cfg *config.Config, db *gorm.DB, opts ...Option) *App {
+	a := build(cfg, repository.NewGormRepositories(db), db, opts)
+	if err := a.Metrics.InstrumentDB(db); err != nil {
+		a.Logger.Error("database metrics disabled", slog.Any("error", err))
+	}
+	if err := telemetry.InstrumentDB(db, a.Tracing); err != nil {
+		a.Logger.Error("database tracing disabled", slog.Any("error", err))
+	}
+	a.OnShutdown(StageDatabase, "database pool", func(context.Context) error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return err
+		}
+		return sqlDB.Close()
+	})
+	return a
+}
+
+// NewWithRepositories creates an application on top of any repository
+// implementation, e.g. repository.NewMemoryRepositories() in tests
+func NewWithRepositories(cfg *config.Config, repos *repository.Repositories, opts ...Option) *App {
+	return build(cfg, repos, nil, opts)
+}
+
+func build(cfg *config.Config, repos *repository.Repositories, db *gorm.DB, opts []Option) *App {
+	a := &App{
+		Config:     cfg,
+		Live:       config.NewStore(cfg),
+		DB:         db,
+		Repos:      repos,
+		Tracing:    noop.NewTracerProvider(),
+		RateLimits: ratelimit.NewMemoryStore(ratelimit.TokenBucketAlgorithm),
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	a.LogLevel.Set(logging.ParseLevel(cfg.App.LogLevel))
+	a.Logger = logging.New(os.Stderr, cfg.App.LogFormat, &a.LogLevel)
+
+	a.Metrics = metrics.New()
+	a.Metrics.RegisterEntityCounts(map[string]metrics.EntityCounter{
+		"movie": repos.Movies.Count,
+		"actor": repos.Actors.Count,
+		"award": repos.Awards.Count,
+	})
+
+	a.Tokens = auth.NewTokens(cfg.Auth)
+	a.Services = services.New(repos, a.Tokens, a.Tracing.Tracer(telemetry.InstrumentationName))
+	a.Handlers = handlers.New(a.Services, handlers.NewHealthHandler(cfg.App, a.readinessChecks()), a.Metrics.Handler())
+
+	// Without trusted proxies X-Forwarded-For is ignored, so callers cannot
+	// pick the IP their anonymous rate limit is keyed by
+	a.Router = gin.New()
+	if err := a.Router.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		a.Logger.Error("invalid trusted proxies, trusting none", slog.Any("error", err))
+		_ = a.Router.SetTrustedProxies(nil)
+	}
+	a.Router.Use(otelgin.Middleware(cfg.Telemetry.ServiceName,
+		otelgin.WithTracerProvider(a.Tracing),
+		otelgin.WithPropagators(telemetry.Propagator()),
+	))
+	a.Router.Use(middleware.RequestID(), middleware.RequestLogger(a.Logger), a.Metrics.Middleware(), middleware.Recovery(a.Logger))
+	a.Router.Use(middleware.CORS(func() []string {
+		return a.Live.Current().Server.CORS.AllowedOrigins
+	}))
+	routes.SetupRoutes(a.Router, a.Handlers, a.Live, middleware.Authenticate(a.Tokens, a.Services.APIKeys.Authenticate), a.rateLimit)
+	return a
+}
+
+// rateLimit throttles a route group with the live rate_limit settings
+func (a *App) rateLimit(group string) gin.HandlerFunc {
+	return middleware.RateLimit(group, a.RateLimits, func(group string) (ratelimit.Limit, bool) {
+		settings := a.Live.Current().RateLimit
+		limit := settings.Group(group)
+		return ratelimit.Limit{Rate: limit.RequestsPerSecond, Burst: limit.Burst}, settings.Enabled
+	})
+}
+
+// readinessChecks lists the dependencies /readyz verifies
+func (a *App) readinessChecks() []handlers.ReadinessCheck {
+	checks := []handlers.ReadinessCheck{
+		{Name: "server", Check: func(context.Context) error {
+			if a.draining.Load() {
+				return errors.New("shutting down")
+			}
+			return nil
+		}},
+	}
+	if a.DB == nil {
+		return checks
+	}
+
+	migrator, migratorErr := migrations.NewMigrator(a.DB)
+	return append(checks,
+		handlers.ReadinessCheck{Name: "database", Check: func(ctx context.Context) error {
+			sqlDB, err := a.DB.DB()
+			if err != nil {
+				return err
+			}
+			return sqlDB.PingContext(ctx)
+		}},
+		handlers.ReadinessCheck{Name: "migrations", Check: func(ctx context.Context) error {
+			if migratorErr != nil {
+				return migratorErr
+			}
+			pending, err := migrator.WithContext(ctx).Pending()
+			if err != nil {
+				return err
+			}
+			if len(pending) > 0 {
+				return fmt.Errorf("%d pending migration(s), next %04d_%s", len(pending), pending[0].Version, pending[0].Name)
+			}
+			return nil
+		}},
+	)
+}
+
+// WatchConfig reloads runtime settings from the config at path on file
+// change or SIGHUP until shutdown
+func (a *App) WatchConfig(path string) {
+	reloader := config.NewReloader(path, a.Live)
+	reloader.OnReload(func(_, cfg *config.Config) {
+		a.LogLevel.Set(logging.ParseLevel(cfg.App.LogLevel))
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if err := reloader.Watch(ctx); err != nil {
+			a.Logger.Error("config hot reload disabled", slog.Any("error", err))
+		}
+	}()
+
+	a.OnShutdown(StageWorkers, "config watcher", func(ctx context.Context) error {
+		cancel()
+		select {
+		case <-done:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+}
+
+// Addr is the host:port the server listens on
+func (a *App) Addr() string {
+	return fmt.Sprintf("%s:%d", a.Config.Server.Host, a.Config.Server.Port)
+}
@@ -0,0 +1,110 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sort"
+	"time"
+
+	"gmdb/config"
+)
+
+// ErrShutdownTimeout is returned when teardown does not finish within
+// server.shutdown_timeout
+var ErrShutdownTimeout = errors.New("shutdown timed out")
+
+// ShutdownStage orders teardown: everything in an earlier stage is stopped
+// before the next stage starts
+type ShutdownStage int
+
+const (
+	StageHTTP     ShutdownStage = iota // stop accepting requests and drain in-flight ones
+	StageWorkers                       // stop background goroutines
+	StageDatabase                      // close connection pools last
+)
+
+// shutdownHook stops one component during teardown
+type shutdownHook struct {
+	stage ShutdownStage
+	name  string
+	stop  func(ctx context.Context) error
+}
+
+// OnShutdown registers stop to run during teardown. Hooks run by stage and,
+// within a stage, in registration order; ctx expires at the shutdown deadline.
+func (a *App) OnShutdown(stage ShutdownStage, name string, stop func(ctx context.Context) error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.hooks = append(a.hooks, shutdownHook{stage: stage, name: name, stop: stop})
+}
+
+// Run serves HTTP until ctx is cancelled (e.g. by SIGTERM), then shuts down.
+// It returns ErrShutdownTimeout if draining did not finish in time.
+func (a *App) Run(ctx context.Context) error {
+	srv := &http.Server{
+		Addr:    a.Addr(),
+		Handler: a.Router,
+	}
+	a.OnShutdown(StageHTTP, "http server", srv.Shutdown)
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serveErr:
+		// The listener failed (e.g. port in use); still release everything else
+		if shutdownErr := a.Shutdown(); shutdownErr != nil {
+			a.Logger.Error("shutdown after server failure", slog.Any("error", shutdownErr))
+		}
+		return fmt.Errorf("http server: %w", err)
+	case <-ctx.Done():
+		a.draining.Store(true)
+		a.Logger.Info("shutting down, waiting for in-flight requests", slog.Duration("timeout", a.shutdownTimeout()))
+	}
+
+	return a.Shutdown()
+}
+
+// Shutdown runs every registered hook once, sharing one deadline
+func (a *App) Shutdown() error {
+	a.mu.Lock()
+	hooks := a.hooks
+	a.hooks = nil
+	a.mu.Unlock()
+
+	sort.SliceStable(hooks, func(i, j int) bool {
+		return hooks[i].stage < hooks[j].stage
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.shutdownTimeout())
+	defer cancel()
+
+	var errs []error
+	for _, hook := range hooks {
+		start := time.Now()
+		if err := hook.stop(ctx); err != nil {
+			a.Logger.Error("stopping component failed", slog.String("component", hook.name),
+				slog.Duration("elapsed", time.Since(start).Round(time.Millisecond)), slog.Any("error", err))
+			errs = append(errs, fmt.Errorf("%s: %w", hook.name, err))
+			continue
+		}
+		a.Logger.Info("stopped component", slog.String("component", hook.name))
+	}
+
+	if ctx.Err() != nil {
+		errs = append([]error{ErrShutdownTimeout}, errs...)
+	}
+	return errors.Join(errs...)
+}
+
+func (a *App) shutdownTimeout() time.Duration {
+	if a.Config.Server.ShutdownTimeout > 0 {
+		return a.Config.Server.ShutdownTimeout
+	}
+	return config.DefaultShutdownTimeout
+}
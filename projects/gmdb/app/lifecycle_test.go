@@ -0,0 +1,98 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"gmdb/config"
+	"gmdb/repository"
+)
+
+func newTestApp(timeout time.Duration) *App {
+	cfg := &config.Config{Server: config.ServerConfig{Host: "127.0.0.1", ShutdownTimeout: timeout}}
+	return NewWithRepositories(cfg, repository.NewMemoryRepositories())
+}
+
+func TestShutdownRunsHooksByStage(t *testing.T) {
+	a := newTestApp(time.Second)
+
+	var order []string
+	record := func(name string) func(context.Context) error {
+		return func(context.Context) error {
+			order = append(order, name)
+			return nil
+		}
+	}
+	a.OnShutdown(StageDatabase, "db", record("db"))
+	a.OnShutdown(StageWorkers, "worker a", record("worker a"))
+	a.OnShutdown(StageHTTP, "http", record("http"))
+	a.OnShutdown(StageWorkers, "worker b", record("worker b"))
+
+	if err := a.Shutdown(); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	if got := strings.Join(order, ","); got != "http,worker a,worker b,db" {
+		t.Fatalf("order = %s", got)
+	}
+
+	// Hooks only run once
+	if err := a.Shutdown(); err != nil || len(order) != 4 {
+		t.Fatalf("second Shutdown ran hooks again: %v %v", err, order)
+	}
+}
+
+func TestShutdownTimeout(t *testing.T) {
+	a := newTestApp(20 * time.Millisecond)
+
+	dbClosed := false
+	a.OnShutdown(StageHTTP, "slow drain", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	a.OnShutdown(StageDatabase, "db", func(context.Context) error {
+		dbClosed = true
+		return nil
+	})
+
+	err := a.Shutdown()
+	if !errors.Is(err, ErrShutdownTimeout) {
+		t.Fatalf("err = %v, want ErrShutdownTimeout", err)
+	}
+	if !dbClosed {
+		t.Fatal("later stages must still run after a timeout")
+	}
+}
+
+func TestRunStopsOnCancel(t *testing.T) {
+	a := newTestApp(time.Second)
+
+	stopped := make(chan struct{})
+	a.OnShutdown(StageWorkers, "worker", func(context.Context) error {
+		close(stopped)
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- a.Run(ctx) }()
+
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Run: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Run did not return after cancel")
+	}
+	select {
+	case <-stopped:
+	default:
+		t.Fatal("worker hook did not run")
+	}
+}
@@ -0,0 +1,91 @@
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"gmdb/config"
+	"gmdb/models"
+	"gmdb/repository"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracingSpans(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	cfg := &config.Config{Auth: config.AuthConfig{SigningKey: "tracing-test-signing-key-0123456789", AccessTokenTTL: time.Minute}}
+	a := NewWithRepositories(cfg, repository.NewMemoryRepositories(), WithTracerProvider(tp))
+	token, _, err := a.Tokens.Issue(uuid.New(), models.RoleAdmin)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The caller's W3C trace context is continued
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/actors/", strings.NewReader(`{"name":"Ada Lovelace"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+	w := httptest.NewRecorder()
+	a.Router.ServeHTTP(w, req)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("status = %d", w.Code)
+	}
+
+	spans := exporter.GetSpans()
+	byName := make(map[string]tracetest.SpanStub)
+	for _, span := range spans {
+		byName[span.Name] = span
+	}
+	server, ok := byName["POST /api/v1/actors/"]
+	if !ok {
+		t.Fatalf("no server span in %v", spanNames(spans))
+	}
+	service, ok := byName["ActorService.CreateActor"]
+	if !ok {
+		t.Fatalf("no service span in %v", spanNames(spans))
+	}
+	if server.SpanContext.TraceID().String() != traceID {
+		t.Errorf("trace ID = %s, want the traceparent's", server.SpanContext.TraceID())
+	}
+	if service.Parent.SpanID() != server.SpanContext.SpanID() {
+		t.Error("service span is not a child of the server span")
+	}
+
+	// A domain error is recorded by code without failing the span
+	exporter.Reset()
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/actors/01900000-0000-7000-8000-000000000000", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	a.Router.ServeHTTP(httptest.NewRecorder(), req)
+	for _, span := range exporter.GetSpans() {
+		if span.Name != "ActorService.GetActor" {
+			continue
+		}
+		if span.Status.Code == codes.Error {
+			t.Error("not found marked the span as failed")
+		}
+		for _, attr := range span.Attributes {
+			if attr.Key == "gmdb.error_code" && attr.Value.AsString() == "actor_not_found" {
+				return
+			}
+		}
+		t.Errorf("attributes = %v", span.Attributes)
+		return
+	}
+	t.Fatal("no ActorService.GetActor span")
+}
+
+func spanNames(spans tracetest.SpanStubs) []string {
+	names := make([]string, len(spans))
+	for i, span := range spans {
+		names[i] = span.Name
+	}
+	return names
+}
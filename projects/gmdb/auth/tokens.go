@@ -0,0 +1,124 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"gmdb/config"
+	"gmdb/models"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// Errors returned by Tokens
+var (
+	ErrInvalidToken = errors.New("invalid or expired access token")
+	ErrNoSigningKey = errors.New("no auth signing key configured")
+)
+
+// claims are the JWT claims of an access token
+type claims struct {
+	Role models.Role `json:"role"`
+	jwt.RegisteredClaims
+}
+
+// Tokens issues and verifies HS256 access tokens and sets how long refresh
+// tokens live. The current signing key signs; previous keys only verify, so
+// keys can be rotated without signing everyone out. Tokens is safe for
+// concurrent use.
+type Tokens struct {
+	issuer     string
+	ttl        time.Duration
+	refreshTTL time.Duration
+	currentKID string
+	keys       map[string][]byte // by key ID, sent as the JWT kid header
+}
+
+// NewTokens creates an issuer from cfg. Without a signing key every Issue
+// fails with ErrNoSigningKey; Config.Validate reports that at startup.
+func NewTokens(cfg config.AuthConfig) *Tokens {
+	t := &Tokens{
+		issuer:     cfg.Issuer,
+		ttl:        cfg.AccessTokenTTL,
+		refreshTTL: cfg.RefreshTokenTTL,
+		keys:       make(map[string][]byte),
+	}
+	for _, key := range cfg.PreviousSigningKeys {
+		if key != "" {
+			t.keys[keyID(key)] = []byte(key)
+		}
+	}
+	if cfg.SigningKey != "" {
+		t.currentKID = keyID(cfg.SigningKey)
+		t.keys[t.currentKID] = []byte(cfg.SigningKey)
+	}
+	return t
+}
+
+// RefreshTTL is how long a refresh token stays valid
+func (t *Tokens) RefreshTTL() time.Duration {
+	return t.refreshTTL
+}
+
+// keyID names a key without revealing it
+func keyID(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:8])
+}
+
+// Issue signs an access token for the user, returning it with its expiry
+func (t *Tokens) Issue(userID uuid.UUID, role models.Role) (string, time.Time, error) {
+	if t.currentKID == "" {
+		return "", time.Time{}, ErrNoSigningKey
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(t.ttl)
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims{
+		Role: role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    t.issuer,
+			Subject:   userID.String(),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	})
+	token.Header["kid"] = t.currentKID
+
+	signed, err := token.SignedString(t.keys[t.currentKID])
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to sign access token: %w", err)
+	}
+	return signed, expiresAt, nil
+}
+
+// Verify checks an access token's signature, issuer and expiry and returns
+// the caller it identifies; every failure is ErrInvalidToken
+func (t *Tokens) Verify(token string) (Principal, error) {
+	var c claims
+	_, err := jwt.ParseWithClaims(token, &c, func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		key, ok := t.keys[kid]
+		if !ok {
+			return nil, fmt.Errorf("unknown key ID %q", kid)
+		}
+		return key, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(t.issuer),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return Principal{}, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+
+	userID, err := uuid.Parse(c.Subject)
+	if err != nil || !c.Role.Valid() {
+		return Principal{}, fmt.Errorf("%w: malformed claims", ErrInvalidToken)
+	}
+	return Principal{UserID: userID, Role: c.Role}, nil
+}
@@ -0,0 +1,80 @@
+package auth
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"gmdb/config"
+	"gmdb/models"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+const (
+	oldKey = "old-signing-key-0123456789abcdefghij"
+	newKey = "new-signing-key-0123456789abcdefghij"
+)
+
+func TestTokensKeyRotation(t *testing.T) {
+	cfg := config.AuthConfig{SigningKey: oldKey, Issuer: "gmdb", AccessTokenTTL: time.Minute}
+	old := NewTokens(cfg)
+	userID := uuid.New()
+	token, expiresAt, err := old.Issue(userID, models.RoleEditor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := time.Until(expiresAt); d <= 0 || d > time.Minute {
+		t.Fatalf("expires in %s", d)
+	}
+
+	// After rotation the old key still verifies, the new one signs
+	cfg.SigningKey, cfg.PreviousSigningKeys = newKey, []string{oldKey}
+	rotated := NewTokens(cfg)
+	principal, err := rotated.Verify(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if principal.UserID != userID || principal.Role != models.RoleEditor {
+		t.Fatalf("principal = %+v", principal)
+	}
+
+	// Once the old key is dropped its tokens are rejected
+	cfg.PreviousSigningKeys = nil
+	if _, err := NewTokens(cfg).Verify(token); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("dropped key: err = %v", err)
+	}
+}
+
+func TestTokensRejectInvalid(t *testing.T) {
+	cfg := config.AuthConfig{SigningKey: newKey, Issuer: "gmdb", AccessTokenTTL: time.Minute}
+	tokens := NewTokens(cfg)
+
+	expired := NewTokens(config.AuthConfig{SigningKey: newKey, Issuer: "gmdb", AccessTokenTTL: -time.Minute})
+	otherIssuer := NewTokens(config.AuthConfig{SigningKey: newKey, Issuer: "other", AccessTokenTTL: time.Minute})
+	unsigned, err := jwt.NewWithClaims(jwt.SigningMethodNone, jwt.MapClaims{
+		"iss": "gmdb", "sub": uuid.NewString(), "role": "admin", "exp": time.Now().Add(time.Hour).Unix(),
+	}).SignedString(jwt.UnsafeAllowNoneSignatureType)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]string{"garbage": "not-a-jwt", "alg none": unsigned}
+	for name, issuer := range map[string]*Tokens{"expired": expired, "other issuer": otherIssuer} {
+		token, _, err := issuer.Issue(uuid.New(), models.RoleAdmin)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cases[name] = token
+	}
+	for name, token := range cases {
+		if _, err := tokens.Verify(token); !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("%s: err = %v", name, err)
+		}
+	}
+
+	if _, _, err := NewTokens(config.AuthConfig{}).Issue(uuid.New(), models.RoleReader); !errors.Is(err, ErrNoSigningKey) {
+		t.Errorf("no signing key: err = %v", err)
+	}
+}
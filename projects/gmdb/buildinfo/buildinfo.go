@@ -0,0 +1,46 @@
+// Package buildinfo exposes values stamped into the binary at link time:
+//
+//	go build -ldflags "-X gmdb/buildinfo.Commit=$(git rev-parse --short HEAD) \
+//	  -X gmdb/buildinfo.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+package buildinfo
+
+import "runtime/debug"
+
+// Set with -ldflags -X; left empty, they fall back to the VCS data Go embeds
+var (
+	Commit    string
+	BuildTime string
+)
+
+// Info describes the running binary
+type Info struct {
+	Commit    string
+	BuildTime string
+	GoVersion string
+}
+
+// Get returns the linker-provided values, falling back to the module's
+// embedded VCS stamp and finally to "unknown"
+func Get() Info {
+	info := Info{Commit: Commit, BuildTime: BuildTime, GoVersion: "unknown"}
+
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		info.GoVersion = bi.GoVersion
+		for _, setting := range bi.Settings {
+			switch {
+			case setting.Key == "vcs.revision" && info.Commit == "":
+				info.Commit = setting.Value
+			case setting.Key == "vcs.time" && info.BuildTime == "":
+				info.BuildTime = setting.Value
+			}
+		}
+	}
+
+	if info.Commit == "" {
+		info.Commit = "unknown"
+	}
+	if info.BuildTime == "" {
+		info.BuildTime = "unknown"
+	}
+	return info
+}
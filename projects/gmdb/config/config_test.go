@@ -0,0 +1,213 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadConfigEnvOverrides(t *testing.T) {
+	path := writeFile(t, "gmdb.yaml", "database:\n  host: filehost\n  user: fileuser\nserver:\n  port: 8080\n")
+	t.Setenv("GMDB_DATABASE_HOST", "envhost")
+	t.Setenv("GMDB_DATABASE_DBNAME", "envdb") // not in the file at all
+	t.Setenv("GMDB_SERVER_SHUTDOWN_TIMEOUT", "3s")
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Database.Host != "envhost" || cfg.Database.DBName != "envdb" || cfg.Database.User != "fileuser" {
+		t.Fatalf("database = %+v", cfg.Database)
+	}
+	if cfg.Server.ShutdownTimeout != 3*time.Second || cfg.Server.Port != 8080 {
+		t.Fatalf("server = %+v", cfg.Server)
+	}
+}
+
+func TestLoadConfigFileIsOptional(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("GMDB_APP_NAME", "from-env")
+
+	cfg, err := LoadConfig("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.App.Name != "from-env" || cfg.Server.Port != 8080 {
+		t.Fatalf("cfg = %+v", cfg)
+	}
+
+	if _, err := LoadConfig("missing.yaml"); err == nil {
+		t.Fatal("an explicit config path must exist")
+	}
+}
+
+func TestLoadConfigFileSecrets(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("GMDB_DATABASE_PASSWORD_FILE", writeFile(t, "password", "s3cret\n"))
+
+	cfg, err := LoadConfig("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Database.Password != "s3cret" {
+		t.Fatalf("password = %q", cfg.Database.Password)
+	}
+
+	t.Setenv("GMDB_DATABASE_PASSWORD", "plain")
+	if _, err := LoadConfig(""); err == nil {
+		t.Fatal("setting both the variable and its _FILE must fail")
+	}
+}
+
+func TestRedactedYAML(t *testing.T) {
+	cfg := Config{
+		Database: DatabaseConfig{
+			Password: "s3cret",
+			URL:      "postgres://gmdb:s3cret@db:5432/gmdb?sslmode=require",
+		},
+		Auth: AuthConfig{SigningKey: "s3cret-signing-key", PreviousSigningKeys: []string{"old-s3cret-key"}},
+	}
+
+	var out strings.Builder
+	if err := cfg.Redacted().WriteYAML(&out); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(out.String(), "s3cret") {
+		t.Fatalf("secret leaked:\n%s", out.String())
+	}
+	if !strings.Contains(out.String(), "url: postgres://gmdb:REDACTED@db:5432/gmdb?sslmode=require") {
+		t.Fatalf("unexpected output:\n%s", out.String())
+	}
+	if got := redactDSN("host=db password='a b' user=gmdb"); got != "host=db password=REDACTED user=gmdb" {
+		t.Fatalf("keyword DSN = %q", got)
+	}
+}
+
+func TestValidateReportsEveryProblem(t *testing.T) {
+	path := writeFile(t, "bad.yaml", `
+database:
+  host: ""
+  sslmode: sometimes
+  log_level: loud
+  max_open_conns: 5
+  max_idle_conns: 10
+server:
+  port: 0
+  trusted_proxies: [10.0.0.0/8, proxy.internal]
+app:
+  environment: prod
+rate_limit:
+  groups:
+    admin: {requests_per_second: 1, burst: 1}
+    auth: {requests_per_second: 1, burst: 0}
+auth:
+  signing_key: too-short
+  access_token_ttl: 1h
+  refresh_token_ttl: 30m
+`)
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = cfg.Validate()
+	verr, ok := err.(*ValidationError)
+	if !ok {
+		t.Fatalf("err = %v, want *ValidationError", err)
+	}
+	got := make(map[string]bool)
+	for _, p := range verr.Problems {
+		got[p.Key] = true
+	}
+	for _, key := range []string{
+		"database.host", "database.user", "database.dbname", "database.sslmode",
+		"database.log_level", "database.max_idle_conns", "server.port", "server.trusted_proxies", "app.environment",
+		"auth.signing_key", "auth.refresh_token_ttl", "rate_limit.groups.admin", "rate_limit.groups.auth.burst",
+	} {
+		if !got[key] {
+			t.Errorf("missing problem for %s in %v", key, verr.Problems)
+		}
+	}
+}
+
+func TestValidateRejectsSampleSigningKeyWhenDeployed(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("GMDB_DATABASE_URL", "postgres://gmdb:pw@db:5432/gmdb?sslmode=require")
+	t.Setenv("GMDB_AUTH_SIGNING_KEY", SampleSigningKey)
+
+	cfg, err := LoadConfig("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("development: %v", err)
+	}
+
+	for _, env := range []string{"staging", "production"} {
+		cfg.App.Environment = env
+		cfg.Auth.SigningKey = SampleSigningKey
+		cfg.Auth.PreviousSigningKeys = nil
+		verr, ok := cfg.Validate().(*ValidationError)
+		if !ok || len(verr.Problems) != 1 || verr.Problems[0].Key != "auth.signing_key" {
+			t.Fatalf("%s signing key: %v", env, verr)
+		}
+
+		// A rotated-out sample key would still verify forged tokens
+		cfg.Auth.SigningKey = "deployed-test-signing-key-0123456789"
+		cfg.Auth.PreviousSigningKeys = []string{SampleSigningKey}
+		verr, ok = cfg.Validate().(*ValidationError)
+		if !ok || len(verr.Problems) != 1 || verr.Problems[0].Key != "auth.previous_signing_keys" {
+			t.Fatalf("%s previous keys: %v", env, verr)
+		}
+	}
+}
+
+func TestValidateSectionsSkipsOtherSections(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("GMDB_DATABASE_URL", "postgres://gmdb:pw@db:5432/gmdb?sslmode=require")
+	t.Setenv("GMDB_APP_ENVIRONMENT", "production")
+	t.Setenv("GMDB_AUTH_SIGNING_KEY", SampleSigningKey)
+
+	cfg, err := LoadConfig("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Migrations don't sign tokens, so the sample key must not block them
+	if err := cfg.ValidateSections(SectionDatabase, SectionApp); err != nil {
+		t.Fatalf("ValidateSections: %v", err)
+	}
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("Validate accepted the sample signing key in production")
+	}
+
+	cfg.Database.URL = "not a url"
+	verr, ok := cfg.ValidateSections(SectionDatabase).(*ValidationError)
+	if !ok || len(verr.Problems) != 1 || verr.Problems[0].Key != "database.url" {
+		t.Fatalf("database: %v", verr)
+	}
+}
+
+func TestValidateAcceptsURLForm(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("GMDB_DATABASE_URL", "postgres://gmdb:pw@db:5432/gmdb?sslmode=require")
+	t.Setenv("GMDB_AUTH_SIGNING_KEY", "url-form-test-signing-key-0123456789")
+
+	cfg, err := LoadConfig("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+}
@@ -1,63 +1,136 @@
 package config
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"gmdb/logging"
 	"gmdb/models"
-	"log"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
 )
 
-var DB *gorm.DB
+// maxConnectBackoff caps the wait between connection attempts
+const maxConnectBackoff = 30 * time.Second
+
+// connectPingTimeout bounds a single connection attempt
+const connectPingTimeout = 5 * time.Second
+
+// gormLogLevels maps database.log_level onto GORM's logger
+var gormLogLevels = map[string]logger.LogLevel{
+	"silent": logger.Silent,
+	"error":  logger.Error,
+	"warn":   logger.Warn,
+	"info":   logger.Info,
+}
 
-func ConnectDB() {
-	if GlobalConfig == nil {
-		log.Fatal("Config not loaded. Call LoadConfig first.")
+// ConnectDB opens a connection pool for the given database settings. It
+// retries with exponential backoff so the server can start before PostgreSQL.
+func ConnectDB(cfg DatabaseConfig) (*gorm.DB, error) {
+	connConfig, err := pgx.ParseConfig(cfg.DSN())
+	if err != nil {
+		return nil, fmt.Errorf("invalid database connection settings: %w", err)
+	}
+	if cfg.StatementTimeout > 0 {
+		connConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(cfg.StatementTimeout.Milliseconds(), 10)
 	}
 
-	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%d sslmode=%s",
-		GlobalConfig.Database.Host,
-		GlobalConfig.Database.User,
-		GlobalConfig.Database.Password,
-		GlobalConfig.Database.DBName,
-		GlobalConfig.Database.Port,
-		GlobalConfig.Database.SSLMode,
-	)
+	logLevel, ok := gormLogLevels[cfg.LogLevel]
+	if !ok {
+		return nil, fmt.Errorf("unknown database log_level %q", cfg.LogLevel)
+	}
+
+	sqlDB := stdlib.OpenDB(*connConfig)
+	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+
+	if err := pingWithRetry(sqlDB, cfg.ConnectRetries, cfg.ConnectBackoff); err != nil {
+		sqlDB.Close()
+		return nil, err
+	}
 
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
-		DisableForeignKeyConstraintWhenMigrating: true,
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{
+		TranslateError: true,
+		Logger:         logging.GormLogger{Level: logLevel, SlowThreshold: cfg.SlowQueryThreshold},
 	})
 	if err != nil {
-		log.Fatal("Failed to connect to database:", err)
-	}
-	DB = db
-	log.Printf("Connected to database: %s@%s:%d/%s",
-		GlobalConfig.Database.User,
-		GlobalConfig.Database.Host,
-		GlobalConfig.Database.Port,
-		GlobalConfig.Database.DBName,
+		sqlDB.Close()
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	slog.Info("connected to database",
+		slog.String("user", connConfig.User),
+		slog.String("host", connConfig.Host),
+		slog.Int("port", int(connConfig.Port)),
+		slog.String("database", connConfig.Database),
 	)
 
-	// Run migrations
-	RunMigrations()
+	// Use the custom join model for the movie/actor association
+	if err := registerJoinTables(db); err != nil {
+		sqlDB.Close()
+		return nil, err
+	}
+	return db, nil
 }
 
-// RunMigrations creates all tables from models
-func RunMigrations() {
-	log.Println("Running database migrations...")
-
-	if err := DB.AutoMigrate(
-		&models.Actor{},
-		&models.Movie{},
-		&models.Award{},
-	); err != nil {
-		log.Fatal("Failed to run migrations:", err)
+// DSN returns URL when set, otherwise a keyword connection string built
+// from the individual fields
+func (cfg DatabaseConfig) DSN() string {
+	if cfg.URL != "" {
+		return cfg.URL
 	}
+	return fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%d sslmode=%s",
+		cfg.Host,
+		cfg.User,
+		cfg.Password,
+		cfg.DBName,
+		cfg.Port,
+		cfg.SSLMode,
+	)
+}
+
+// pingWithRetry waits for the database, making up to retries extra attempts
+func pingWithRetry(db *sql.DB, retries int, backoff time.Duration) error {
+	var err error
+	for attempt := 0; ; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), connectPingTimeout)
+		err = db.PingContext(ctx)
+		cancel()
+		if err == nil || attempt >= retries {
+			break
+		}
 
-	log.Println("Migrations completed successfully!")
+		slog.Warn("database not reachable, retrying",
+			slog.Int("attempt", attempt+1),
+			slog.Int("attempts", retries+1),
+			slog.Duration("backoff", backoff),
+			slog.Any("error", err),
+		)
+		time.Sleep(backoff)
+		backoff = min(backoff*2, maxConnectBackoff)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to connect to database after %d attempt(s): %w", retries+1, err)
+	}
+	return nil
 }
 
-func GetDB() *gorm.DB {
-	return DB
+// registerJoinTables tells GORM that movie_actors carries role columns
+func registerJoinTables(db *gorm.DB) error {
+	if err := db.SetupJoinTable(&models.Movie{}, "Actors", &models.MovieActor{}); err != nil {
+		return fmt.Errorf("failed to set up movie_actors join table: %w", err)
+	}
+	if err := db.SetupJoinTable(&models.Actor{}, "Movies", &models.MovieActor{}); err != nil {
+		return fmt.Errorf("failed to set up movie_actors join table: %w", err)
+	}
+	return nil
 }
@@ -0,0 +1,115 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// redactedValue replaces secrets in printed configuration
+const redactedValue = "REDACTED"
+
+// dsnPasswordPattern matches password=... in a keyword connection string
+var dsnPasswordPattern = regexp.MustCompile(`(password=)('(?:[^'\\]|\\.)*'|\S+)`)
+
+// Redacted returns a copy of the config with every secret masked
+func (c Config) Redacted() Config {
+	if c.Database.Password != "" {
+		c.Database.Password = redactedValue
+	}
+	c.Database.URL = redactDSN(c.Database.URL)
+	if c.Auth.SigningKey != "" {
+		c.Auth.SigningKey = redactedValue
+	}
+	if len(c.Auth.PreviousSigningKeys) > 0 {
+		keys := make([]string, len(c.Auth.PreviousSigningKeys))
+		for i := range keys {
+			keys[i] = redactedValue
+		}
+		c.Auth.PreviousSigningKeys = keys
+	}
+	return c
+}
+
+// redactDSN masks the password of a URL or keyword connection string
+func redactDSN(dsn string) string {
+	if dsn == "" {
+		return dsn
+	}
+	if u, err := url.Parse(dsn); err == nil && u.Scheme != "" {
+		if _, ok := u.User.Password(); ok {
+			u.User = url.UserPassword(u.User.Username(), redactedValue)
+		}
+		query := u.Query()
+		if query.Has("password") {
+			query.Set("password", redactedValue)
+			u.RawQuery = query.Encode()
+		}
+		return u.String()
+	}
+	return dsnPasswordPattern.ReplaceAllString(dsn, "${1}"+redactedValue)
+}
+
+// WriteYAML writes the config as YAML using the same keys as the config file
+func (c Config) WriteYAML(w io.Writer) error {
+	node, err := yamlNode(reflect.ValueOf(c))
+	if err != nil {
+		return err
+	}
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(node); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// yamlNode converts a config struct into a YAML mapping in field order,
+// naming fields by their mapstructure tag and printing durations as "15s".
+// Maps are printed in key order, their values converted the same way.
+func yamlNode(v reflect.Value) (*yaml.Node, error) {
+	if d, ok := v.Interface().(time.Duration); ok {
+		return &yaml.Node{Kind: yaml.ScalarNode, Value: d.String()}, nil
+	}
+	if v.Kind() == reflect.Map && v.Type().Key().Kind() == reflect.String {
+		keys := v.MapKeys()
+		slices.SortFunc(keys, func(a, b reflect.Value) int { return strings.Compare(a.String(), b.String()) })
+		mapping := &yaml.Node{Kind: yaml.MappingNode}
+		for _, key := range keys {
+			value, err := yamlNode(v.MapIndex(key))
+			if err != nil {
+				return nil, err
+			}
+			mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key.String()}, value)
+		}
+		return mapping, nil
+	}
+	if v.Kind() != reflect.Struct {
+		node := &yaml.Node{}
+		if err := node.Encode(v.Interface()); err != nil {
+			return nil, fmt.Errorf("failed to encode config value: %w", err)
+		}
+		return node, nil
+	}
+
+	mapping := &yaml.Node{Kind: yaml.MappingNode}
+	for i := 0; i < v.NumField(); i++ {
+		name := v.Type().Field(i).Tag.Get("mapstructure")
+		if name == "" || name == "-" {
+			continue
+		}
+		value, err := yamlNode(v.Field(i))
+		if err != nil {
+			return nil, err
+		}
+		mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: name}, value)
+	}
+	return mapping, nil
+}
@@ -0,0 +1,205 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// reloadableKeys are the settings a running server picks up without a
+// restart; a key matches itself and everything below it
+var reloadableKeys = []string{
+	"app.log_level",
+	"server.cors.allowed_origins",
+	"rate_limit",
+	"features",
+}
+
+// reloadDebounce coalesces the burst of events editors emit for one save
+const reloadDebounce = 200 * time.Millisecond
+
+// Store holds the live configuration; readers always see a complete,
+// validated Config and never a partially applied reload
+type Store struct {
+	current atomic.Pointer[Config]
+}
+
+// NewStore creates a store holding cfg
+func NewStore(cfg *Config) *Store {
+	s := &Store{}
+	s.current.Store(cfg)
+	return s
+}
+
+// Current returns the live configuration; callers must not modify it
+func (s *Store) Current() *Config {
+	return s.current.Load()
+}
+
+// Change is one setting that differs between two configs
+type Change struct {
+	Key string
+	Old any
+	New any
+}
+
+// Reloader re-reads the config file and swaps reloadable settings into a Store
+type Reloader struct {
+	path  string
+	store *Store
+
+	mu        sync.Mutex // serialises reloads
+	listeners []func(old, new *Config)
+}
+
+// NewReloader creates a reloader for the config at path ("" for the default file)
+func NewReloader(path string, store *Store) *Reloader {
+	return &Reloader{path: path, store: store}
+}
+
+// OnReload registers fn to run after each applied reload
+func (r *Reloader) OnReload(fn func(old, new *Config)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.listeners = append(r.listeners, fn)
+}
+
+// Reload loads and validates the config, applies its reloadable settings and
+// returns what changed. Settings that need a restart keep their old values
+// and are logged as ignored. An invalid config leaves the store untouched.
+func (r *Reloader) Reload(trigger string) ([]Change, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	next, err := LoadConfig(r.path)
+	if err == nil {
+		err = next.Validate()
+	}
+	if err != nil {
+		slog.Error("config reload rejected, keeping current config", slog.String("trigger", trigger), slog.Any("error", err))
+		return nil, err
+	}
+
+	old := r.store.Current()
+	applied := *old
+	var changes []Change
+	for _, change := range diffConfigs(old, next) {
+		if !isReloadable(change.Key) {
+			slog.Warn("config change ignored, it requires a restart", slog.String("trigger", trigger), slog.String("key", change.Key))
+			continue
+		}
+		changes = append(changes, change)
+	}
+	if len(changes) == 0 {
+		slog.Info("config reloaded without changes", slog.String("trigger", trigger))
+		return nil, nil
+	}
+
+	applied.App.LogLevel = next.App.LogLevel
+	applied.Server.CORS = next.Server.CORS
+	applied.RateLimit = next.RateLimit
+	applied.Features = next.Features
+	r.store.current.Store(&applied)
+
+	// Audit trail: reloadable settings hold no secrets, so values are logged
+	for _, change := range changes {
+		slog.Info("config changed", slog.String("trigger", trigger), slog.String("key", change.Key),
+			slog.Any("old", change.Old), slog.Any("new", change.New))
+	}
+	for _, fn := range r.listeners {
+		fn(old, &applied)
+	}
+	return changes, nil
+}
+
+// Watch reloads on SIGHUP and whenever the config file changes, until ctx
+// is cancelled
+func (r *Reloader) Watch(ctx context.Context) error {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	var fileEvents <-chan fsnotify.Event
+	var watchErrors <-chan error
+	path := r.path
+	if path == "" {
+		path = DefaultConfigFile
+	}
+	if _, err := os.Stat(path); err == nil {
+		watcher, err := fsnotify.NewWatcher()
+		if err != nil {
+			return fmt.Errorf("failed to watch config: %w", err)
+		}
+		defer watcher.Close()
+
+		// Watch the directory: editors and Kubernetes replace files rather than write them
+		if err := watcher.Add(filepath.Dir(path)); err != nil {
+			return fmt.Errorf("failed to watch config: %w", err)
+		}
+		fileEvents, watchErrors = watcher.Events, watcher.Errors
+	}
+
+	var debounce <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-hup:
+			r.Reload("SIGHUP")
+		case event := <-fileEvents:
+			if filepath.Clean(event.Name) == filepath.Clean(path) || filepath.Base(event.Name) == "..data" {
+				debounce = time.After(reloadDebounce)
+			}
+		case <-debounce:
+			debounce = nil
+			r.Reload("file change")
+		case err := <-watchErrors:
+			slog.Error("config watcher failed", slog.Any("error", err))
+		}
+	}
+}
+
+func isReloadable(key string) bool {
+	return slices.ContainsFunc(reloadableKeys, func(prefix string) bool {
+		return key == prefix || strings.HasPrefix(key, prefix+".")
+	})
+}
+
+// diffConfigs lists the leaf settings that differ, keyed like the config file
+func diffConfigs(old, next *Config) []Change {
+	var changes []Change
+	diffValues(reflect.ValueOf(*old), reflect.ValueOf(*next), "", &changes)
+	return changes
+}
+
+func diffValues(old, next reflect.Value, prefix string, changes *[]Change) {
+	if old.Kind() == reflect.Struct {
+		for i := 0; i < old.NumField(); i++ {
+			name := old.Type().Field(i).Tag.Get("mapstructure")
+			if name == "" || name == "-" {
+				continue
+			}
+			diffValues(old.Field(i), next.Field(i), prefix+name+".", changes)
+		}
+		return
+	}
+	if !reflect.DeepEqual(old.Interface(), next.Interface()) {
+		*changes = append(*changes, Change{
+			Key: strings.TrimSuffix(prefix, "."),
+			Old: old.Interface(),
+			New: next.Interface(),
+		})
+	}
+}
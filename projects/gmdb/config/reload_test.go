@@ -0,0 +1,92 @@
+package config
+
+import (
+	"os"
+	"slices"
+	"testing"
+)
+
+const reloadBase = `database:
+  url: postgres://gmdb:pw@db:5432/gmdb
+server:
+  port: 8080
+app:
+  log_level: info
+features:
+  search: true
+auth:
+  signing_key: reload-test-signing-key-0123456789abcdef
+`
+
+func TestReloadAppliesRuntimeSettings(t *testing.T) {
+	path := writeFile(t, "gmdb.yaml", reloadBase)
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := NewStore(cfg)
+	reloader := NewReloader(path, store)
+	var notified *Config
+	reloader.OnReload(func(_, next *Config) { notified = next })
+
+	updated := `database:
+  url: postgres://gmdb:pw@other:5432/gmdb
+server:
+  port: 9090
+  cors:
+    allowed_origins: [https://app.example]
+app:
+  log_level: debug
+features:
+  search: false
+auth:
+  signing_key: reload-test-signing-key-0123456789abcdef
+`
+	if err := os.WriteFile(path, []byte(updated), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	changes, err := reloader.Reload("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var keys []string
+	for _, change := range changes {
+		keys = append(keys, change.Key)
+	}
+	want := []string{"server.cors.allowed_origins", "app.log_level", "features"}
+	if !slices.Equal(keys, want) {
+		t.Fatalf("changed keys = %v, want %v", keys, want)
+	}
+
+	live := store.Current()
+	if live.App.LogLevel != "debug" || live.Enabled("search") || !slices.Equal(live.Server.CORS.AllowedOrigins, []string{"https://app.example"}) {
+		t.Fatalf("runtime settings not applied: %+v", live)
+	}
+	// Structural settings need a restart and keep their startup values
+	if live.Server.Port != 8080 || live.Database.URL != cfg.Database.URL {
+		t.Fatalf("structural settings changed: port %d, url %q", live.Server.Port, live.Database.URL)
+	}
+	if notified != live {
+		t.Fatal("listener was not called with the applied config")
+	}
+}
+
+func TestReloadRejectsInvalidConfig(t *testing.T) {
+	path := writeFile(t, "gmdb.yaml", reloadBase)
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := NewStore(cfg)
+
+	if err := os.WriteFile(path, []byte(reloadBase+"rate_limit:\n  burst: -1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewReloader(path, store).Reload("test"); err == nil {
+		t.Fatal("invalid config was accepted")
+	}
+	if store.Current() != cfg {
+		t.Fatal("store changed after a rejected reload")
+	}
+}
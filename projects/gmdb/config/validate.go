@@ -0,0 +1,256 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Allowed values of enum settings
+var (
+	Environments = []string{"development", "test", "staging", "production"}
+	SSLModes     = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+	LogLevels    = []string{"silent", "error", "warn", "info"}
+	AppLogLevels = []string{"debug", "info", "warn", "error"}
+	LogFormats   = []string{"json", "text"}
+	// RateLimitGroups are the route groups with their own rate limits: the
+	// API resources, login and token refresh, and search
+	RateLimitGroups = []string{"api", "auth", "search"}
+)
+
+// Problem is one invalid setting
+type Problem struct {
+	Key     string // dotted config key, e.g. database.port
+	Message string
+}
+
+// ValidationError lists every invalid setting of a config
+type ValidationError struct {
+	Problems []Problem
+}
+
+func (e *ValidationError) Error() string {
+	lines := make([]string, len(e.Problems))
+	for i, p := range e.Problems {
+		lines[i] = fmt.Sprintf("  %s: %s", p.Key, p.Message)
+	}
+	return fmt.Sprintf("invalid configuration (%d problem(s)):\n%s", len(e.Problems), strings.Join(lines, "\n"))
+}
+
+// problems collects validation failures
+type problems []Problem
+
+func (p *problems) add(key, format string, args ...any) {
+	*p = append(*p, Problem{Key: key, Message: fmt.Sprintf(format, args...)})
+}
+
+func (p problems) err() error {
+	if len(p) == 0 {
+		return nil
+	}
+	return &ValidationError{Problems: p}
+}
+
+// Section is a top-level config key that can be validated on its own
+type Section string
+
+// Config sections, in the order Validate checks them
+const (
+	SectionDatabase  Section = "database"
+	SectionServer    Section = "server"
+	SectionApp       Section = "app"
+	SectionRateLimit Section = "rate_limit"
+	SectionTelemetry Section = "telemetry"
+	SectionAuth      Section = "auth"
+)
+
+// Sections lists every config section
+var Sections = []Section{SectionDatabase, SectionServer, SectionApp, SectionRateLimit, SectionTelemetry, SectionAuth}
+
+// Validate checks every setting and reports all problems at once as a
+// *ValidationError
+func (c *Config) Validate() error {
+	return c.ValidateSections(Sections...)
+}
+
+// ValidateSections checks only the given sections, so commands that never
+// serve requests are not blocked by settings they don't use
+func (c *Config) ValidateSections(sections ...Section) error {
+	var p problems
+	for _, section := range Sections {
+		if !slices.Contains(sections, section) {
+			continue
+		}
+		switch section {
+		case SectionDatabase:
+			c.Database.validate(&p)
+		case SectionServer:
+			c.Server.validate(&p)
+		case SectionApp:
+			c.App.validate(&p)
+		case SectionRateLimit:
+			c.RateLimit.validate(&p)
+		case SectionTelemetry:
+			c.Telemetry.validate(&p)
+		case SectionAuth:
+			c.Auth.validate(&p, c.App.Environment)
+		}
+	}
+	return p.err()
+}
+
+func (d DatabaseConfig) validate(p *problems) {
+	if d.URL != "" {
+		if _, err := pgx.ParseConfig(d.URL); err != nil {
+			p.add("database.url", "is not a valid connection string: %v", err)
+		}
+	} else {
+		if d.Host == "" {
+			p.add("database.host", "is required (or set database.url)")
+		}
+		checkPort(p, "database.port", d.Port)
+		if d.User == "" {
+			p.add("database.user", "is required (or set database.url)")
+		}
+		if d.DBName == "" {
+			p.add("database.dbname", "is required (or set database.url)")
+		}
+		checkEnum(p, "database.sslmode", d.SSLMode, SSLModes)
+	}
+
+	if d.MaxOpenConns < 0 {
+		p.add("database.max_open_conns", "must not be negative (0 means unlimited)")
+	}
+	if d.MaxIdleConns < 0 {
+		p.add("database.max_idle_conns", "must not be negative")
+	} else if d.MaxOpenConns > 0 && d.MaxIdleConns > d.MaxOpenConns {
+		p.add("database.max_idle_conns", "must not exceed max_open_conns (%d)", d.MaxOpenConns)
+	}
+	if d.ConnectRetries < 0 {
+		p.add("database.connect_retries", "must not be negative")
+	}
+	checkDuration(p, "database.conn_max_lifetime", d.ConnMaxLifetime)
+	checkDuration(p, "database.conn_max_idle_time", d.ConnMaxIdleTime)
+	checkDuration(p, "database.statement_timeout", d.StatementTimeout)
+	checkDuration(p, "database.connect_backoff", d.ConnectBackoff)
+	checkDuration(p, "database.slow_query_threshold", d.SlowQueryThreshold)
+	checkEnum(p, "database.log_level", d.LogLevel, LogLevels)
+}
+
+func (s ServerConfig) validate(p *problems) {
+	checkPort(p, "server.port", s.Port)
+	checkDuration(p, "server.shutdown_timeout", s.ShutdownTimeout)
+	for _, origin := range s.CORS.AllowedOrigins {
+		if origin == "*" {
+			continue
+		}
+		u, err := url.Parse(origin)
+		if err != nil || u.Scheme == "" || u.Host == "" || (u.Path != "" && u.Path != "/") {
+			p.add("server.cors.allowed_origins", "%q must be \"*\" or scheme://host[:port]", origin)
+		}
+	}
+	for _, proxy := range s.TrustedProxies {
+		if net.ParseIP(proxy) == nil {
+			if _, _, err := net.ParseCIDR(proxy); err != nil {
+				p.add("server.trusted_proxies", "%q must be an IP address or CIDR", proxy)
+			}
+		}
+	}
+}
+
+func (a AppConfig) validate(p *problems) {
+	checkEnum(p, "app.environment", a.Environment, Environments)
+	checkEnum(p, "app.log_level", a.LogLevel, AppLogLevels)
+	checkEnum(p, "app.log_format", a.LogFormat, LogFormats)
+}
+
+func (r RateLimitConfig) validate(p *problems) {
+	if r.RequestsPerSecond < 0 || (r.Enabled && r.RequestsPerSecond == 0) {
+		p.add("rate_limit.requests_per_second", "must be positive, got %g", r.RequestsPerSecond)
+	}
+	if r.Burst < 0 || (r.Enabled && r.Burst == 0) {
+		p.add("rate_limit.burst", "must be at least 1, got %d", r.Burst)
+	}
+	for name, group := range r.Groups {
+		key := "rate_limit.groups." + name
+		if !slices.Contains(RateLimitGroups, name) {
+			p.add(key, "is not a route group, must be one of %s", strings.Join(RateLimitGroups, ", "))
+			continue
+		}
+		if group.RequestsPerSecond <= 0 {
+			p.add(key+".requests_per_second", "must be positive, got %g", group.RequestsPerSecond)
+		}
+		if group.Burst < 1 {
+			p.add(key+".burst", "must be at least 1, got %d", group.Burst)
+		}
+	}
+}
+
+func checkPort(p *problems, key string, port int) {
+	if port < 1 || port > 65535 {
+		p.add(key, "must be between 1 and 65535, got %d", port)
+	}
+}
+
+func checkEnum(p *problems, key, value string, allowed []string) {
+	if !slices.Contains(allowed, value) {
+		p.add(key, "must be one of %s, got %q", strings.Join(allowed, ", "), value)
+	}
+}
+
+func checkDuration(p *problems, key string, d time.Duration) {
+	if d < 0 {
+		p.add(key, "must not be negative, got %s", d)
+	}
+}
+
+func (t TelemetryConfig) validate(p *problems) {
+	if !t.Enabled {
+		return
+	}
+	if t.ServiceName == "" {
+		p.add("telemetry.service_name", "is required when tracing is enabled")
+	}
+	if t.Endpoint == "" {
+		p.add("telemetry.endpoint", "is required when tracing is enabled")
+	} else if strings.Contains(t.Endpoint, "://") {
+		p.add("telemetry.endpoint", "must be host:port without a scheme, got %q", t.Endpoint)
+	}
+	if t.SampleRatio < 0 || t.SampleRatio > 1 {
+		p.add("telemetry.sample_ratio", "must be between 0 and 1, got %g", t.SampleRatio)
+	}
+}
+
+func (a AuthConfig) validate(p *problems, environment string) {
+	deployed := environment == "staging" || environment == "production"
+	if a.SigningKey == "" {
+		p.add("auth.signing_key", "is required")
+	} else if len(a.SigningKey) < MinSigningKeyLength {
+		p.add("auth.signing_key", "must be at least %d bytes, got %d", MinSigningKeyLength, len(a.SigningKey))
+	} else if deployed && a.SigningKey == SampleSigningKey {
+		p.add("auth.signing_key", "is the public sample key from gmdb.yaml; set a secret key in %s", environment)
+	}
+	for _, key := range a.PreviousSigningKeys {
+		if len(key) < MinSigningKeyLength {
+			p.add("auth.previous_signing_keys", "every key must be at least %d bytes", MinSigningKeyLength)
+			break
+		}
+	}
+	if deployed && slices.Contains(a.PreviousSigningKeys, SampleSigningKey) {
+		p.add("auth.previous_signing_keys", "must not include the public sample key from gmdb.yaml in %s", environment)
+	}
+	if a.Issuer == "" {
+		p.add("auth.issuer", "is required")
+	}
+	if a.AccessTokenTTL <= 0 {
+		p.add("auth.access_token_ttl", "must be positive, got %s", a.AccessTokenTTL)
+	}
+	if a.RefreshTokenTTL <= a.AccessTokenTTL {
+		p.add("auth.refresh_token_ttl", "must be longer than access_token_ttl (%s), got %s", a.AccessTokenTTL, a.RefreshTokenTTL)
+	}
+}
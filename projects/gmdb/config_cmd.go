@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"log/slog"
+	"os"
+
+	"gmdb/config"
+	"gmdb/logging"
+
+	"github.com/spf13/cobra"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect the resolved configuration",
+	Long:  "Works with the same file, defaults and GMDB_* environment overrides the server uses.",
+}
+
+var configPrintCmd = &cobra.Command{
+	Use:   "print",
+	Short: "Print the resolved configuration with secrets redacted",
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg, err := config.LoadConfig(configFile)
+		if err != nil {
+			log.Fatal("Failed to load config:", err)
+		}
+
+		if err := cfg.Redacted().WriteYAML(os.Stdout); err != nil {
+			log.Fatal("Failed to print config:", err)
+		}
+	},
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate [file...]",
+	Short: "Check config files and report every invalid setting",
+	Long: "Validates each file (or the --config file) after applying defaults and GMDB_* " +
+		"environment overrides. Exits non-zero if any file is invalid, so CI can run it " +
+		"against deployment configs.",
+	Run: func(cmd *cobra.Command, args []string) {
+		files := args
+		if len(files) == 0 {
+			files = []string{configFile}
+		}
+
+		failed := false
+		for _, file := range files {
+			name := file
+			if name == "" {
+				name = config.DefaultConfigFile
+			}
+
+			cfg, err := config.LoadConfig(file)
+			if err == nil {
+				err = cfg.Validate()
+			}
+			if err != nil {
+				failed = true
+				fmt.Fprintf(os.Stderr, "%s: %v\n", name, err)
+				continue
+			}
+			fmt.Printf("%s: OK\n", name)
+		}
+		if failed {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	configCmd.AddCommand(configPrintCmd, configValidateCmd)
+}
+
+// mustLoadConfig loads the --config file and validates the app section plus
+// the sections the command uses, exiting on any problem, and switches the
+// default logger to the configured format
+func mustLoadConfig(sections ...config.Section) *config.Config {
+	cfg, err := config.LoadConfig(configFile)
+	if err != nil {
+		log.Fatal("Failed to load config:", err)
+	}
+	if err := cfg.ValidateSections(append([]config.Section{config.SectionApp}, sections...)...); err != nil {
+		log.Fatal(err)
+	}
+
+	// From here on every log line, including log.Printf, is structured
+	slog.SetDefault(logging.New(os.Stderr, cfg.App.LogFormat, logging.ParseLevel(cfg.App.LogLevel)))
+	return cfg
+}
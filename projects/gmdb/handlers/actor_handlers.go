@@ -2,7 +2,6 @@ package handlers
 
 import (
 	"net/http"
-	"strconv"
 
 	"gmdb/services"
 	"gmdb/utils"
@@ -11,128 +10,136 @@ import (
 	"github.com/google/uuid"
 )
 
-// Global service instance (in production, inject via dependency injection)
-var actorService *services.ActorService
-
-// InitActorHandlers initializes the handlers with required dependencies
-func InitActorHandlers(service *services.ActorService) {
-	actorService = service
+// ActorHandler serves the actor endpoints
+type ActorHandler struct {
+	service *services.ActorService
 }
 
-// HandleGetActors retrieves all actors with optional pagination
-func HandleGetActors(c *gin.Context) {
-	// Parse query parameters
-	limitStr := c.DefaultQuery("limit", "10")
-	offsetStr := c.DefaultQuery("offset", "0")
+// NewActorHandler creates an actor handler backed by service
+func NewActorHandler(service *services.ActorService) *ActorHandler {
+	return &ActorHandler{service: service}
+}
 
-	limit, _ := strconv.Atoi(limitStr)
-	offset, _ := strconv.Atoi(offsetStr)
+// GetActors retrieves one page of actors
+func (h *ActorHandler) GetActors(c *gin.Context) {
+	// Parse filter, sort and pagination query parameters
+	query, err := parseListQuery(c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
 
 	// Call service
-	actors, err := actorService.GetAllActors(limit, offset)
+	result, err := h.service.GetAllActors(c.Request.Context(), query)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusInternalServerError, err.Error())
+		respondError(c, err)
 		return
 	}
 
-	utils.SuccessResponse(c, http.StatusOK, "Actors retrieved successfully", actors)
+	respondPage(c, "Actors retrieved successfully", result)
 }
 
-// HandleGetActor retrieves a single actor by ID
-func HandleGetActor(c *gin.Context) {
+// GetActor retrieves a single actor by ID
+func (h *ActorHandler) GetActor(c *gin.Context) {
 	// Parse UUID from path
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid actor ID")
+		utils.ErrorResponse(c, http.StatusBadRequest, utils.CodeInvalidID, "Invalid actor ID")
 		return
 	}
 
 	// Call service
-	actor, err := actorService.GetActor(id)
+	actor, err := h.service.GetActor(c.Request.Context(), id)
 	if err != nil {
-		status := http.StatusInternalServerError
-		if err.Error() == "actor not found" {
-			status = http.StatusNotFound
-		}
-		utils.ErrorResponse(c, status, err.Error())
+		respondError(c, err)
 		return
 	}
 
 	utils.SuccessResponse(c, http.StatusOK, "Actor retrieved successfully", actor)
 }
 
-// HandleCreateActor creates a new actor
-func HandleCreateActor(c *gin.Context) {
+// CreateActor creates a new actor
+func (h *ActorHandler) CreateActor(c *gin.Context) {
 	var req services.CreateActorRequest
 
 	// Bind JSON input
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid input: "+err.Error())
+		respondBindingError(c, err)
 		return
 	}
 
 	// Call service (handles all business logic)
-	actor, err := actorService.CreateActor(req)
+	actor, err := h.service.CreateActor(c.Request.Context(), req)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		respondError(c, err)
 		return
 	}
 
 	utils.SuccessResponse(c, http.StatusCreated, "Actor created successfully", actor)
 }
 
-// HandleUpdateActor updates an existing actor
-func HandleUpdateActor(c *gin.Context) {
+// UpdateActor updates an existing actor
+func (h *ActorHandler) UpdateActor(c *gin.Context) {
 	// Parse UUID from path
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid actor ID")
+		utils.ErrorResponse(c, http.StatusBadRequest, utils.CodeInvalidID, "Invalid actor ID")
 		return
 	}
 
 	var req services.CreateActorRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid input: "+err.Error())
+		respondBindingError(c, err)
 		return
 	}
 
 	// Call service
-	actor, err := actorService.UpdateActor(id, req)
+	actor, err := h.service.UpdateActor(c.Request.Context(), id, req)
 	if err != nil {
-		status := http.StatusInternalServerError
-		if err.Error() == "actor not found" {
-			status = http.StatusNotFound
-		} else if err.Error() != "failed to update actor" {
-			status = http.StatusBadRequest
-		}
-		utils.ErrorResponse(c, status, err.Error())
+		respondError(c, err)
 		return
 	}
 
 	utils.SuccessResponse(c, http.StatusOK, "Actor updated successfully", actor)
 }
 
-// HandleDeleteActor soft deletes an actor
-func HandleDeleteActor(c *gin.Context) {
+// DeleteActor soft deletes an actor
+func (h *ActorHandler) DeleteActor(c *gin.Context) {
 	// Parse UUID from path
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid actor ID")
+		utils.ErrorResponse(c, http.StatusBadRequest, utils.CodeInvalidID, "Invalid actor ID")
 		return
 	}
 
 	// Call service
-	if err := actorService.DeleteActor(id); err != nil {
-		status := http.StatusInternalServerError
-		if err.Error() == "actor not found" {
-			status = http.StatusNotFound
-		}
-		utils.ErrorResponse(c, status, err.Error())
+	if err := h.service.DeleteActor(c.Request.Context(), id); err != nil {
+		respondError(c, err)
 		return
 	}
 
 	utils.SuccessResponse(c, http.StatusOK, "Actor deleted successfully", nil)
 }
+
+// RestoreActor undeletes a soft-deleted actor
+func (h *ActorHandler) RestoreActor(c *gin.Context) {
+	// Parse UUID from path
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, utils.CodeInvalidID, "Invalid actor ID")
+		return
+	}
+
+	// Call service
+	actor, err := h.service.RestoreActor(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Actor restored successfully", actor)
+}
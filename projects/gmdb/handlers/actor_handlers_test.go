@@ -0,0 +1,87 @@
+package handlers_test
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestActorCRUD(t *testing.T) {
+	s := newTestServer(t)
+	id := s.createActor("Keanu Reeves", "1964-09-02")
+
+	var actor struct {
+		ID        string `json:"id"`
+		Name      string `json:"name"`
+		BirthDate string `json:"birth_date"`
+	}
+	s.mustDo(http.MethodGet, "/api/v1/actors/"+id, nil, http.StatusOK, &actor)
+	if actor.Name != "Keanu Reeves" || actor.BirthDate != "1964-09-02T00:00:00Z" {
+		t.Fatalf("got %+v", actor)
+	}
+
+	s.mustDo(http.MethodPut, "/api/v1/actors/"+id, map[string]any{"name": "Keanu Charles Reeves"}, http.StatusOK, &actor)
+	if actor.Name != "Keanu Charles Reeves" {
+		t.Fatalf("update: name = %q", actor.Name)
+	}
+
+	s.mustDo(http.MethodDelete, "/api/v1/actors/"+id, nil, http.StatusOK, nil)
+	env := s.mustDo(http.MethodGet, "/api/v1/actors/"+id, nil, http.StatusNotFound, nil)
+	if env.Success || env.Code != "actor_not_found" {
+		t.Fatalf("get after delete: %+v", env)
+	}
+	s.mustDo(http.MethodDelete, "/api/v1/actors/"+id, nil, http.StatusNotFound, nil)
+
+	s.mustDo(http.MethodPost, "/api/v1/actors/"+id+"/restore", nil, http.StatusOK, &actor)
+	if actor.ID != id {
+		t.Fatalf("restore: %+v", actor)
+	}
+	s.mustDo(http.MethodGet, "/api/v1/actors/"+id, nil, http.StatusOK, nil)
+}
+
+func TestActorValidation(t *testing.T) {
+	s := newTestServer(t)
+
+	env := s.mustDo(http.MethodPost, "/api/v1/actors/", map[string]any{"name": "K", "birth_date": "2999-01-01T00:00:00Z"}, http.StatusBadRequest, nil)
+	if env.Code != "validation_failed" {
+		t.Fatalf("code = %q", env.Code)
+	}
+	if !hasDetail(env, "name", "too_short") || !hasDetail(env, "birth_date", "in_future") {
+		t.Fatalf("details = %+v", env.Details)
+	}
+
+	env = s.mustDo(http.MethodPost, "/api/v1/actors/", map[string]any{"birth_date": "2999-01-01T00:00:00Z"}, http.StatusBadRequest, nil)
+	if !hasDetail(env, "name", "required") || !hasDetail(env, "birth_date", "in_future") {
+		t.Fatalf("missing name details = %+v", env.Details)
+	}
+
+	env = s.mustDo(http.MethodGet, "/api/v1/actors/not-a-uuid", nil, http.StatusBadRequest, nil)
+	if env.Code != "invalid_id" {
+		t.Fatalf("invalid id code = %q", env.Code)
+	}
+}
+
+func TestActorFilters(t *testing.T) {
+	s := newTestServer(t)
+	s.createActor("Keanu Reeves", "1964-09-02")
+	s.createActor("Carrie-Anne Moss", "1967-08-21")
+	s.createActor("Hugo Weaving", "")
+
+	var actors []struct {
+		Name string `json:"name"`
+	}
+	s.mustDo(http.MethodGet, "/api/v1/actors/?born_before=1966", nil, http.StatusOK, &actors)
+	if len(actors) != 1 || actors[0].Name != "Keanu Reeves" {
+		t.Fatalf("born_before: %+v", actors)
+	}
+
+	s.mustDo(http.MethodGet, "/api/v1/actors/?name_contains=ANNE", nil, http.StatusOK, &actors)
+	if len(actors) != 1 || actors[0].Name != "Carrie-Anne Moss" {
+		t.Fatalf("name_contains: %+v", actors)
+	}
+
+	// Actors without a birth date sort first
+	s.mustDo(http.MethodGet, "/api/v1/actors/?sort=birth_date", nil, http.StatusOK, &actors)
+	if len(actors) != 3 || actors[0].Name != "Hugo Weaving" || actors[2].Name != "Carrie-Anne Moss" {
+		t.Fatalf("sort=birth_date: %+v", actors)
+	}
+}
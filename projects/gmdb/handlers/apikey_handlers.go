@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"net/http"
+
+	"gmdb/services"
+	"gmdb/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIKeyHandler serves API key management
+type APIKeyHandler struct {
+	service *services.APIKeyService
+}
+
+// NewAPIKeyHandler creates an API key handler backed by service
+func NewAPIKeyHandler(service *services.APIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{service: service}
+}
+
+// CreateAPIKey issues a new key; the response is the only time it is shown
+func (h *APIKeyHandler) CreateAPIKey(c *gin.Context) {
+	var req services.CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindingError(c, err)
+		return
+	}
+
+	key, err := h.service.CreateAPIKey(c.Request.Context(), req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "API key created successfully", key)
+}
+
+// GetAPIKeys lists every API key without its secret
+func (h *APIKeyHandler) GetAPIKeys(c *gin.Context) {
+	keys, err := h.service.ListAPIKeys(c.Request.Context())
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "API keys retrieved successfully", keys)
+}
+
+// RevokeAPIKey revokes the key with the given ID or prefix
+func (h *APIKeyHandler) RevokeAPIKey(c *gin.Context) {
+	if err := h.service.RevokeAPIKey(c.Request.Context(), c.Param("id")); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "API key revoked successfully", nil)
+}
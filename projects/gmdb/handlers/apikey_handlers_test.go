@@ -0,0 +1,115 @@
+package handlers_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"gmdb/auth"
+	"gmdb/models"
+
+	"github.com/google/uuid"
+)
+
+// apiKey is the subset of services.APIKeyResponse the tests need
+type apiKey struct {
+	ID         string         `json:"id"`
+	Key        string         `json:"key"`
+	Prefix     string         `json:"prefix"`
+	Scopes     []models.Scope `json:"scopes"`
+	LastUsedAt *time.Time     `json:"last_used_at"`
+	RevokedAt  *time.Time     `json:"revoked_at"`
+}
+
+// createAPIKey creates a key with scopes as the current (admin) caller
+func (s *testServer) createAPIKey(name string, scopes ...models.Scope) apiKey {
+	s.t.Helper()
+	var key apiKey
+	s.mustDo(http.MethodPost, "/api/v1/api-keys/", map[string]any{"name": name, "scopes": scopes}, http.StatusCreated, &key)
+	return key
+}
+
+func TestAPIKeyScopes(t *testing.T) {
+	s := newTestServer(t)
+	movie := "/api/v1/movies/" + s.createMovie("Heat", 1995, "Crime", 8.3)
+	key := s.createAPIKey("importer", "movies:write", "movies:read", "movies:read")
+	if len(key.Scopes) != 2 || key.Prefix == "" || len(key.Key) <= len(key.Prefix) || key.Key[:len(key.Prefix)] != key.Prefix {
+		t.Fatalf("created key = %+v", key)
+	}
+
+	s.as("")
+	s.apiKey = key.Key
+	s.mustDo(http.MethodGet, movie, nil, http.StatusOK, nil)
+	s.mustDo(http.MethodGet, "/movies/", nil, http.StatusOK, nil) // legacy route
+	s.mustDo(http.MethodPut, movie, map[string]any{"title": "Heat", "year": 1995, "rating": 8.4}, http.StatusOK, nil)
+	for _, path := range []string{"/api/v1/actors/", "/api/v1/search?q=heat", "/api/v1/api-keys/"} {
+		env := s.mustDo(http.MethodGet, path, nil, http.StatusForbidden, nil)
+		if env.Code != "forbidden" {
+			t.Fatalf("GET %s code = %q", path, env.Code)
+		}
+	}
+	s.mustDo(http.MethodDelete, movie, nil, http.StatusForbidden, nil)
+
+	// Use is recorded on the key
+	s.apiKey = ""
+	s.as(models.RoleAdmin)
+	var keys []apiKey
+	s.mustDo(http.MethodGet, "/api/v1/api-keys/", nil, http.StatusOK, &keys)
+	if len(keys) != 1 || keys[0].LastUsedAt == nil || keys[0].Key != "" {
+		t.Fatalf("listed keys = %+v", keys)
+	}
+
+	// Sending both credentials is ambiguous
+	s.apiKey = key.Key
+	env := s.mustDo(http.MethodGet, movie, nil, http.StatusUnauthorized, nil)
+	if env.Code != "unauthorized" {
+		t.Fatalf("both credentials code = %q", env.Code)
+	}
+}
+
+func TestAPIKeyRevokedAndExpired(t *testing.T) {
+	s := newTestServer(t)
+	revoked := s.createAPIKey("revoked", "actors:read")
+	s.mustDo(http.MethodDelete, "/api/v1/api-keys/"+revoked.Prefix, nil, http.StatusOK, nil)
+	s.mustDo(http.MethodDelete, "/api/v1/api-keys/"+revoked.ID, nil, http.StatusNotFound, nil)
+
+	secret, prefix, err := auth.GenerateAPIKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expiredAt := time.Now().Add(-time.Minute)
+	if err := s.app.Repos.APIKeys.Create(context.Background(), &models.APIKey{
+		ID: uuid.New(), Name: "expired", Prefix: prefix, SecretHash: auth.HashSecret(secret),
+		Scopes: models.ScopeList{"actors:read"}, ExpiresAt: &expiredAt,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	s.as("")
+	for name, key := range map[string]string{"revoked": revoked.Key, "expired": secret, "unknown": "gmdb_unknown_key"} {
+		s.apiKey = key
+		env := s.mustDo(http.MethodGet, "/api/v1/actors/", nil, http.StatusUnauthorized, nil)
+		if env.Code != "unauthorized" {
+			t.Fatalf("%s key code = %q", name, env.Code)
+		}
+	}
+}
+
+func TestCreateAPIKeyValidation(t *testing.T) {
+	s := newTestServer(t)
+	env := s.mustDo(http.MethodPost, "/api/v1/api-keys/", map[string]any{
+		"name": " ", "scopes": []string{"movies:admin"}, "expires_at": time.Now().Add(-time.Hour),
+	}, http.StatusBadRequest, nil)
+	if !hasDetail(env, "name", "required") || !hasDetail(env, "scopes", "invalid") || !hasDetail(env, "expires_at", "in_past") {
+		t.Fatalf("details = %+v", env.Details)
+	}
+
+	env = s.mustDo(http.MethodPost, "/api/v1/api-keys/", map[string]any{"expires_at": time.Now().Add(-time.Hour)}, http.StatusBadRequest, nil)
+	if !hasDetail(env, "name", "required") || !hasDetail(env, "scopes", "required") || !hasDetail(env, "expires_at", "in_past") {
+		t.Fatalf("missing fields details = %+v", env.Details)
+	}
+
+	s.as(models.RoleEditor)
+	s.mustDo(http.MethodPost, "/api/v1/api-keys/", map[string]any{"name": "x", "scopes": []string{"movies:read"}}, http.StatusForbidden, nil)
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"net/http"
+
+	"gmdb/services"
+	"gmdb/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuthHandler serves login, token refresh and user management
+type AuthHandler struct {
+	service *services.AuthService
+}
+
+// NewAuthHandler creates an auth handler backed by service
+func NewAuthHandler(service *services.AuthService) *AuthHandler {
+	return &AuthHandler{service: service}
+}
+
+// Login exchanges an email and password for tokens
+func (h *AuthHandler) Login(c *gin.Context) {
+	var req services.LoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindingError(c, err)
+		return
+	}
+
+	tokens, err := h.service.Login(c.Request.Context(), req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Logged in successfully", tokens)
+}
+
+// Refresh exchanges a refresh token for new tokens
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var req services.RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindingError(c, err)
+		return
+	}
+
+	tokens, err := h.service.Refresh(c.Request.Context(), req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Tokens refreshed successfully", tokens)
+}
+
+// Logout revokes a refresh token
+func (h *AuthHandler) Logout(c *gin.Context) {
+	var req services.RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindingError(c, err)
+		return
+	}
+
+	if err := h.service.Logout(c.Request.Context(), req); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Logged out successfully", nil)
+}
+
+// CreateUser registers a new user
+func (h *AuthHandler) CreateUser(c *gin.Context) {
+	var req services.CreateUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindingError(c, err)
+		return
+	}
+
+	user, err := h.service.CreateUser(c.Request.Context(), req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "User created successfully", user)
+}
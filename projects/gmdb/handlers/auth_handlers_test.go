@@ -0,0 +1,129 @@
+package handlers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"gmdb/models"
+)
+
+func TestRoles(t *testing.T) {
+	s := newTestServer(t)
+	id := s.createActor("Keanu Reeves", "")
+	actor := "/api/v1/actors/" + id
+	cast := "/api/v1/movies/" + s.createMovie("Point Break", 1991, "Action", 7.3) + "/actors"
+	update := map[string]any{"name": "Keanu Charles Reeves"}
+
+	s.as("")
+	w, env := s.do(http.MethodGet, actor, nil)
+	if w.Code != http.StatusUnauthorized || env.Code != "unauthorized" || w.Header().Get("WWW-Authenticate") != "Bearer" {
+		t.Fatalf("anonymous read: %d %+v %v", w.Code, env, w.Header())
+	}
+	s.mustDo(http.MethodGet, "/actors/"+id, nil, http.StatusUnauthorized, nil) // legacy route
+	s.mustDo(http.MethodGet, "/healthz", nil, http.StatusOK, nil)
+
+	s.as(models.RoleReader)
+	s.mustDo(http.MethodGet, actor, nil, http.StatusOK, nil)
+	s.mustDo(http.MethodGet, "/api/v1/search?q=keanu", nil, http.StatusOK, nil)
+	env = s.mustDo(http.MethodPut, actor, update, http.StatusForbidden, nil)
+	if env.Code != "forbidden" {
+		t.Fatalf("reader update code = %q", env.Code)
+	}
+
+	s.as(models.RoleEditor)
+	s.mustDo(http.MethodPut, actor, update, http.StatusOK, nil)
+	s.mustDo(http.MethodPost, "/api/v1/movies/", map[string]any{"title": "Speed", "year": 1994}, http.StatusCreated, nil)
+	s.mustDo(http.MethodDelete, actor, nil, http.StatusForbidden, nil)
+	// Editing a cast is an edit of the movie, both ways
+	s.mustDo(http.MethodPost, cast, map[string]any{"actor_id": id}, http.StatusOK, nil)
+	s.mustDo(http.MethodDelete, cast+"/"+id, nil, http.StatusOK, nil)
+	s.mustDo(http.MethodPost, "/api/v1/users/", map[string]any{}, http.StatusForbidden, nil)
+
+	s.as(models.RoleAdmin)
+	s.mustDo(http.MethodDelete, actor, nil, http.StatusOK, nil)
+
+	// Bad credentials are rejected even where a role would not be needed
+	s.token = "not-a-jwt"
+	w, _ = s.do(http.MethodPost, "/api/v1/auth/login", map[string]any{"email": "a@example.com", "password": "x"})
+	if w.Code != http.StatusUnauthorized || w.Header().Get("WWW-Authenticate") != `Bearer error="invalid_token"` {
+		t.Fatalf("invalid token: %d %v", w.Code, w.Header())
+	}
+}
+
+func TestLoginRefreshLogout(t *testing.T) {
+	s := newTestServer(t)
+	s.mustDo(http.MethodPost, "/api/v1/users/", map[string]any{
+		"email": "Editor@Example.com", "password": "correct horse battery", "role": "editor",
+	}, http.StatusCreated, nil)
+
+	s.as("")
+	for _, body := range []map[string]any{
+		{"email": "editor@example.com", "password": "wrong password!"},
+		{"email": "nobody@example.com", "password": "correct horse battery"},
+	} {
+		env := s.mustDo(http.MethodPost, "/api/v1/auth/login", body, http.StatusUnauthorized, nil)
+		if env.Code != "invalid_credentials" {
+			t.Fatalf("login %v: code = %q", body["email"], env.Code)
+		}
+	}
+	env := s.mustDo(http.MethodPost, "/api/v1/auth/login", map[string]any{}, http.StatusBadRequest, nil)
+	if !hasDetail(env, "email", "required") || !hasDetail(env, "password", "required") {
+		t.Fatalf("empty login details = %+v", env.Details)
+	}
+
+	var tokens struct {
+		AccessToken  string      `json:"access_token"`
+		TokenType    string      `json:"token_type"`
+		ExpiresIn    int         `json:"expires_in"`
+		RefreshToken string      `json:"refresh_token"`
+		Role         models.Role `json:"role"`
+	}
+	s.mustDo(http.MethodPost, "/api/v1/auth/login", map[string]any{
+		"email": "EDITOR@example.com", "password": "correct horse battery",
+	}, http.StatusOK, &tokens)
+	if tokens.TokenType != "Bearer" || tokens.ExpiresIn != 60 || tokens.Role != models.RoleEditor || tokens.RefreshToken == "" {
+		t.Fatalf("tokens = %+v", tokens)
+	}
+
+	s.token = tokens.AccessToken
+	s.createMovie("Heat", 1995, "Crime", 8.3)
+	s.token = ""
+
+	// Refresh tokens are single use
+	used := tokens.RefreshToken
+	s.mustDo(http.MethodPost, "/api/v1/auth/refresh", map[string]any{"refresh_token": used}, http.StatusOK, &tokens)
+	if tokens.RefreshToken == used || tokens.AccessToken == "" {
+		t.Fatalf("refresh did not rotate: %+v", tokens)
+	}
+	env = s.mustDo(http.MethodPost, "/api/v1/auth/refresh", map[string]any{"refresh_token": used}, http.StatusUnauthorized, nil)
+	if env.Code != "invalid_refresh_token" {
+		t.Fatalf("reused refresh token code = %q", env.Code)
+	}
+
+	s.mustDo(http.MethodPost, "/api/v1/auth/logout", map[string]any{"refresh_token": tokens.RefreshToken}, http.StatusOK, nil)
+	s.mustDo(http.MethodPost, "/api/v1/auth/refresh", map[string]any{"refresh_token": tokens.RefreshToken}, http.StatusUnauthorized, nil)
+}
+
+func TestCreateUserValidation(t *testing.T) {
+	s := newTestServer(t)
+
+	env := s.mustDo(http.MethodPost, "/api/v1/users/", map[string]any{
+		"email": "not an email", "password": "short", "role": "owner",
+	}, http.StatusBadRequest, nil)
+	if !hasDetail(env, "email", "invalid") || !hasDetail(env, "password", "too_short") || !hasDetail(env, "role", "invalid") {
+		t.Fatalf("details = %+v", env.Details)
+	}
+
+	env = s.mustDo(http.MethodPost, "/api/v1/users/", map[string]any{}, http.StatusBadRequest, nil)
+	if !hasDetail(env, "email", "required") || !hasDetail(env, "password", "required") || !hasDetail(env, "role", "required") {
+		t.Fatalf("missing fields details = %+v", env.Details)
+	}
+
+	user := map[string]any{"email": "ada@example.com", "password": "correct horse battery", "role": "reader"}
+	s.mustDo(http.MethodPost, "/api/v1/users/", user, http.StatusCreated, nil)
+	user["email"] = "ADA@example.com"
+	env = s.mustDo(http.MethodPost, "/api/v1/users/", user, http.StatusConflict, nil)
+	if env.Code != "email_taken" {
+		t.Fatalf("duplicate email code = %q", env.Code)
+	}
+}
@@ -3,25 +3,182 @@ package handlers
 import (
 	"net/http"
 
+	"gmdb/services"
+	"gmdb/utils"
+
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
-func HandleGetAwards(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{"message": "Hello, World!"})
+// AwardHandler serves the award endpoints
+type AwardHandler struct {
+	service *services.AwardService
+}
+
+// NewAwardHandler creates an award handler backed by service
+func NewAwardHandler(service *services.AwardService) *AwardHandler {
+	return &AwardHandler{service: service}
+}
+
+// GetAwards retrieves one page of awards
+func (h *AwardHandler) GetAwards(c *gin.Context) {
+	// Parse filter, sort and pagination query parameters
+	query, err := parseListQuery(c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	// Call service
+	result, err := h.service.GetAllAwards(c.Request.Context(), query)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respondPage(c, "Awards retrieved successfully", result)
+}
+
+// GetAwardsGrouped retrieves one page of awards grouped by year and category
+func (h *AwardHandler) GetAwardsGrouped(c *gin.Context) {
+	// Parse filter and pagination query parameters
+	query, err := parseListQuery(c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	// Call service
+	groups, err := h.service.GetAwardsGrouped(c.Request.Context(), query)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respondPage(c, "Awards retrieved successfully", groups)
+}
+
+// GetAward retrieves a single award by ID
+func (h *AwardHandler) GetAward(c *gin.Context) {
+	// Parse UUID from path
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, utils.CodeInvalidID, "Invalid award ID")
+		return
+	}
+
+	// Call service
+	award, err := h.service.GetAward(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Award retrieved successfully", award)
 }
 
-func HandleGetAward(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{"message": "Hello, World!"})
+// CreateAward creates a new award
+func (h *AwardHandler) CreateAward(c *gin.Context) {
+	var req services.CreateAwardRequest
+
+	// Bind JSON input
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindingError(c, err)
+		return
+	}
+
+	// Call service (handles all business logic)
+	award, err := h.service.CreateAward(c.Request.Context(), req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Award created successfully", award)
 }
 
-func HandleCreateAward(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{"message": "Hello, World!"})
+// CreateAwardGroup creates several awards sharing a year and category
+func (h *AwardHandler) CreateAwardGroup(c *gin.Context) {
+	var req services.CreateAwardGroupRequest
+
+	// Bind JSON input
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindingError(c, err)
+		return
+	}
+
+	// Call service
+	group, err := h.service.CreateAwardGroup(c.Request.Context(), req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Awards created successfully", group)
 }
 
-func HandleUpdateAward(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{"message": "Hello, World!"})
+// UpdateAward updates an existing award
+func (h *AwardHandler) UpdateAward(c *gin.Context) {
+	// Parse UUID from path
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, utils.CodeInvalidID, "Invalid award ID")
+		return
+	}
+
+	var req services.CreateAwardRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindingError(c, err)
+		return
+	}
+
+	// Call service
+	award, err := h.service.UpdateAward(c.Request.Context(), id, req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Award updated successfully", award)
 }
 
-func HandleDeleteAward(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{"message": "Hello, World!"})
+// DeleteAward soft deletes an award
+func (h *AwardHandler) DeleteAward(c *gin.Context) {
+	// Parse UUID from path
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, utils.CodeInvalidID, "Invalid award ID")
+		return
+	}
+
+	// Call service
+	if err := h.service.DeleteAward(c.Request.Context(), id); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Award deleted successfully", nil)
+}
+
+// RestoreAward undeletes a soft-deleted award
+func (h *AwardHandler) RestoreAward(c *gin.Context) {
+	// Parse UUID from path
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, utils.CodeInvalidID, "Invalid award ID")
+		return
+	}
+
+	// Call service
+	award, err := h.service.RestoreAward(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Award restored successfully", award)
 }
@@ -0,0 +1,153 @@
+package handlers_test
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestAwardCRUD(t *testing.T) {
+	s := newTestServer(t)
+	movieID := s.createMovie("Parasite", 2019, "Thriller", 8.5)
+
+	var award struct {
+		ID      string  `json:"id"`
+		Name    string  `json:"name"`
+		MovieID *string `json:"movie_id"`
+	}
+	s.mustDo(http.MethodPost, "/api/v1/awards/", map[string]any{
+		"name": "Best Picture", "category": "Academy Awards", "year": 2020, "movie_id": movieID,
+	}, http.StatusCreated, &award)
+	if award.MovieID == nil || *award.MovieID != movieID {
+		t.Fatalf("created %+v", award)
+	}
+
+	s.mustDo(http.MethodPut, "/api/v1/awards/"+award.ID, map[string]any{
+		"name": "Best Director", "category": "Academy Awards", "year": 2020, "movie_id": movieID,
+	}, http.StatusOK, &award)
+	if award.Name != "Best Director" {
+		t.Fatalf("updated %+v", award)
+	}
+
+	s.mustDo(http.MethodDelete, "/api/v1/awards/"+award.ID, nil, http.StatusOK, nil)
+	env := s.mustDo(http.MethodGet, "/api/v1/awards/"+award.ID, nil, http.StatusNotFound, nil)
+	if env.Code != "award_not_found" {
+		t.Fatalf("code = %q", env.Code)
+	}
+
+	s.mustDo(http.MethodPost, "/api/v1/awards/"+award.ID+"/restore", nil, http.StatusOK, &award)
+	if award.Name != "Best Director" {
+		t.Fatalf("restore: %+v", award)
+	}
+	s.mustDo(http.MethodGet, "/api/v1/awards/"+award.ID, nil, http.StatusOK, nil)
+}
+
+func TestAwardValidation(t *testing.T) {
+	s := newTestServer(t)
+	missing := "01900000-0000-7000-8000-000000000000"
+
+	env := s.mustDo(http.MethodPost, "/api/v1/awards/", map[string]any{"name": "Best Picture", "year": 1800}, http.StatusBadRequest, nil)
+	if !hasDetail(env, "year", "out_of_range") || !hasDetail(env, "movie_id", "required_without") || !hasDetail(env, "actor_id", "required_without") {
+		t.Fatalf("details = %+v", env.Details)
+	}
+
+	env = s.mustDo(http.MethodPost, "/api/v1/awards/", map[string]any{"year": 1800}, http.StatusBadRequest, nil)
+	if !hasDetail(env, "name", "required") || !hasDetail(env, "year", "out_of_range") || !hasDetail(env, "movie_id", "required_without") {
+		t.Fatalf("missing name details = %+v", env.Details)
+	}
+
+	env = s.mustDo(http.MethodPost, "/api/v1/awards/grouped", map[string]any{}, http.StatusBadRequest, nil)
+	if !hasDetail(env, "year", "required") || !hasDetail(env, "category", "required") || !hasDetail(env, "recipients", "required") {
+		t.Fatalf("empty group details = %+v", env.Details)
+	}
+
+	// Exactly one recipient: a movie and an actor together are rejected
+	movieID := s.createMovie("Oppenheimer", 2023, "Drama", 8.4)
+	actorID := s.createActor("Cillian Murphy", "1976-05-25")
+	env = s.mustDo(http.MethodPost, "/api/v1/awards/", map[string]any{"name": "Best Actor", "movie_id": movieID, "actor_id": actorID}, http.StatusBadRequest, nil)
+	if !hasDetail(env, "actor_id", "excluded_with") {
+		t.Fatalf("details = %+v", env.Details)
+	}
+
+	env = s.mustDo(http.MethodPost, "/api/v1/awards/", map[string]any{"name": "Best Actor", "actor_id": missing}, http.StatusBadRequest, nil)
+	if !hasDetail(env, "actor_id", "not_found") {
+		t.Fatalf("details = %+v", env.Details)
+	}
+}
+
+func TestAwardGroups(t *testing.T) {
+	s := newTestServer(t)
+	movieID := s.createMovie("Oppenheimer", 2023, "Drama", 8.4)
+	actorID := s.createActor("Cillian Murphy", "1976-05-25")
+
+	// One invalid recipient rejects the whole group
+	env := s.mustDo(http.MethodPost, "/api/v1/awards/grouped", map[string]any{
+		"year": 2024, "category": "Academy Awards",
+		"recipients": []map[string]any{
+			{"name": "Best Picture", "movie_id": movieID},
+			{"name": "Best Actor"},
+		},
+	}, http.StatusBadRequest, nil)
+	if !hasDetail(env, "recipients[1].movie_id", "required_without") {
+		t.Fatalf("details = %+v", env.Details)
+	}
+	var awards []any
+	s.mustDo(http.MethodGet, "/api/v1/awards/", nil, http.StatusOK, &awards)
+	if len(awards) != 0 {
+		t.Fatalf("rejected group left %d awards", len(awards))
+	}
+
+	s.mustDo(http.MethodPost, "/api/v1/awards/grouped", map[string]any{
+		"year": 2024, "category": "Academy Awards",
+		"recipients": []map[string]any{
+			{"name": "Best Picture", "movie_id": movieID},
+			{"name": "Best Actor", "actor_id": actorID},
+		},
+	}, http.StatusCreated, nil)
+	s.mustDo(http.MethodPost, "/api/v1/awards/", map[string]any{
+		"name": "Best Actor - Drama", "category": "Golden Globes", "year": 2024, "actor_id": actorID,
+	}, http.StatusCreated, nil)
+	s.mustDo(http.MethodPost, "/api/v1/awards/", map[string]any{
+		"name": "Best Picture", "category": "Academy Awards", "year": 2011, "movie_id": movieID,
+	}, http.StatusCreated, nil)
+
+	var groups []struct {
+		Year       int `json:"year"`
+		Categories []struct {
+			Category string `json:"category"`
+			Awards   []struct {
+				Name string `json:"name"`
+			} `json:"awards"`
+		} `json:"categories"`
+	}
+	s.mustDo(http.MethodGet, "/api/v1/awards/grouped", nil, http.StatusOK, &groups)
+	if len(groups) != 2 || groups[0].Year != 2024 || groups[1].Year != 2011 {
+		t.Fatalf("groups = %+v", groups)
+	}
+	latest := groups[0].Categories
+	if len(latest) != 2 || latest[0].Category != "Academy Awards" || latest[1].Category != "Golden Globes" {
+		t.Fatalf("categories = %+v", latest)
+	}
+	if len(latest[0].Awards) != 2 || latest[0].Awards[0].Name != "Best Actor" {
+		t.Fatalf("awards = %+v", latest[0].Awards)
+	}
+
+	// Pages are bounded by award count and continue with the cursor
+	var page []struct {
+		Year int `json:"year"`
+	}
+	env = s.mustDo(http.MethodGet, "/api/v1/awards/grouped?limit=3", nil, http.StatusOK, &page)
+	if len(page) != 1 || page[0].Year != 2024 || env.Meta == nil || !env.Meta.HasMore {
+		t.Fatalf("first page = %+v, meta = %+v", page, env.Meta)
+	}
+	env = s.mustDo(http.MethodGet, "/api/v1/awards/grouped?limit=3&cursor="+env.Meta.NextCursor, nil, http.StatusOK, &page)
+	if len(page) != 1 || page[0].Year != 2011 || env.Meta.HasMore {
+		t.Fatalf("second page = %+v, meta = %+v", page, env.Meta)
+	}
+	s.mustDo(http.MethodGet, "/api/v1/awards/grouped?sort=name", nil, http.StatusBadRequest, nil)
+
+	var filtered []any
+	s.mustDo(http.MethodGet, "/api/v1/awards/?actor_id="+actorID+"&category=golden%20globes", nil, http.StatusOK, &filtered)
+	if len(filtered) != 1 {
+		t.Fatalf("filtered = %+v", filtered)
+	}
+}
@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"net/http"
+
+	"gmdb/services"
+	"gmdb/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// CastHandler serves the movie cast and filmography endpoints
+type CastHandler struct {
+	service *services.CastService
+}
+
+// NewCastHandler creates a cast handler backed by service
+func NewCastHandler(service *services.CastService) *CastHandler {
+	return &CastHandler{service: service}
+}
+
+// GetMovieCast retrieves the actors credited in a movie
+func (h *CastHandler) GetMovieCast(c *gin.Context) {
+	movieID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, utils.CodeInvalidID, "Invalid movie ID")
+		return
+	}
+
+	cast, err := h.service.GetMovieCast(c.Request.Context(), movieID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Movie cast retrieved successfully", cast)
+}
+
+// AddMovieActor adds an actor to a movie's cast
+func (h *CastHandler) AddMovieActor(c *gin.Context) {
+	movieID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, utils.CodeInvalidID, "Invalid movie ID")
+		return
+	}
+
+	var req services.AddCastMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindingError(c, err)
+		return
+	}
+
+	member, err := h.service.AddCastMember(c.Request.Context(), movieID, req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Actor added to movie successfully", member)
+}
+
+// RemoveMovieActor removes an actor from a movie's cast
+func (h *CastHandler) RemoveMovieActor(c *gin.Context) {
+	movieID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, utils.CodeInvalidID, "Invalid movie ID")
+		return
+	}
+	actorID, err := uuid.Parse(c.Param("actor_id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, utils.CodeInvalidID, "Invalid actor ID")
+		return
+	}
+
+	if err := h.service.RemoveCastMember(c.Request.Context(), movieID, actorID); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Actor removed from movie successfully", nil)
+}
+
+// GetActorMovies retrieves the movies an actor is credited in
+func (h *CastHandler) GetActorMovies(c *gin.Context) {
+	actorID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, utils.CodeInvalidID, "Invalid actor ID")
+		return
+	}
+
+	movies, err := h.service.GetActorMovies(c.Request.Context(), actorID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Actor movies retrieved successfully", movies)
+}
@@ -0,0 +1,87 @@
+package handlers_test
+
+import (
+	"net/http"
+	"testing"
+)
+
+type castMember struct {
+	Name          string `json:"name"`
+	CharacterName string `json:"character_name"`
+	BillingOrder  int    `json:"billing_order"`
+	CreditType    string `json:"credit_type"`
+}
+
+func TestCast(t *testing.T) {
+	s := newTestServer(t)
+	matrix := s.createMovie("The Matrix", 1999, "Sci-Fi", 8.7)
+	johnWick := s.createMovie("John Wick", 2014, "Action", 7.4)
+	keanu := s.createActor("Keanu Reeves", "1964-09-02")
+	carrie := s.createActor("Carrie-Anne Moss", "1967-08-21")
+	hugo := s.createActor("Hugo Weaving", "1960-04-04")
+
+	s.mustDo(http.MethodPost, "/api/v1/movies/"+matrix+"/actors", map[string]any{"actor_id": hugo}, http.StatusOK, nil)
+	s.mustDo(http.MethodPost, "/api/v1/movies/"+matrix+"/actors", map[string]any{"actor_id": carrie, "billing_order": 2}, http.StatusOK, nil)
+	s.mustDo(http.MethodPost, "/api/v1/movies/"+matrix+"/actors", map[string]any{"actor_id": keanu, "billing_order": 1, "character_name": "Neo"}, http.StatusOK, nil)
+	s.mustDo(http.MethodPost, "/api/v1/movies/"+johnWick+"/actors", map[string]any{"actor_id": keanu, "credit_type": "lead"}, http.StatusOK, nil)
+
+	// Adding again updates the credit instead of duplicating it
+	var member castMember
+	s.mustDo(http.MethodPost, "/api/v1/movies/"+matrix+"/actors", map[string]any{
+		"actor_id": keanu, "billing_order": 1, "character_name": "Neo", "credit_type": "lead",
+	}, http.StatusOK, &member)
+	if member.Name != "Keanu Reeves" || member.CreditType != "lead" {
+		t.Fatalf("upsert = %+v", member)
+	}
+
+	var cast []castMember
+	s.mustDo(http.MethodGet, "/api/v1/movies/"+matrix+"/actors", nil, http.StatusOK, &cast)
+	if len(cast) != 3 || cast[0].CharacterName != "Neo" || cast[1].Name != "Carrie-Anne Moss" || cast[2].CreditType != "supporting" {
+		t.Fatalf("cast = %+v", cast)
+	}
+
+	var films []struct {
+		Title      string `json:"title"`
+		CreditType string `json:"credit_type"`
+	}
+	s.mustDo(http.MethodGet, "/api/v1/actors/"+keanu+"/movies", nil, http.StatusOK, &films)
+	if len(films) != 2 || films[0].Title != "The Matrix" || films[1].Title != "John Wick" {
+		t.Fatalf("filmography = %+v", films)
+	}
+
+	// Soft-deleted actors drop out of the cast
+	s.mustDo(http.MethodDelete, "/api/v1/actors/"+hugo, nil, http.StatusOK, nil)
+	s.mustDo(http.MethodDelete, "/api/v1/movies/"+matrix+"/actors/"+carrie, nil, http.StatusOK, nil)
+	s.mustDo(http.MethodDelete, "/api/v1/movies/"+matrix+"/actors/"+carrie, nil, http.StatusOK, nil)
+	s.mustDo(http.MethodGet, "/api/v1/movies/"+matrix+"/actors", nil, http.StatusOK, &cast)
+	if len(cast) != 1 || cast[0].Name != "Keanu Reeves" {
+		t.Fatalf("cast after removals = %+v", cast)
+	}
+}
+
+func TestCastErrors(t *testing.T) {
+	s := newTestServer(t)
+	movie := s.createMovie("Heat", 1995, "Crime", 8.3)
+	actor := s.createActor("Al Pacino", "1940-04-25")
+	missing := "01900000-0000-7000-8000-000000000000"
+
+	env := s.mustDo(http.MethodPost, "/api/v1/movies/"+missing+"/actors", map[string]any{"actor_id": actor}, http.StatusNotFound, nil)
+	if env.Code != "movie_not_found" {
+		t.Fatalf("code = %q", env.Code)
+	}
+	env = s.mustDo(http.MethodPost, "/api/v1/movies/"+movie+"/actors", map[string]any{"actor_id": missing}, http.StatusNotFound, nil)
+	if env.Code != "actor_not_found" {
+		t.Fatalf("code = %q", env.Code)
+	}
+	env = s.mustDo(http.MethodPost, "/api/v1/movies/"+movie+"/actors", map[string]any{
+		"actor_id": actor, "billing_order": -1, "credit_type": "extra",
+	}, http.StatusBadRequest, nil)
+	if !hasDetail(env, "billing_order", "out_of_range") || !hasDetail(env, "credit_type", "invalid_choice") {
+		t.Fatalf("details = %+v", env.Details)
+	}
+	env = s.mustDo(http.MethodPost, "/api/v1/movies/"+movie+"/actors", map[string]any{"billing_order": -1}, http.StatusBadRequest, nil)
+	if !hasDetail(env, "actor_id", "required") || !hasDetail(env, "billing_order", "out_of_range") {
+		t.Fatalf("missing actor details = %+v", env.Details)
+	}
+	s.mustDo(http.MethodGet, "/api/v1/actors/"+missing+"/movies", nil, http.StatusNotFound, nil)
+}
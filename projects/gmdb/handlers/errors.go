@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"gmdb/services"
+	"gmdb/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// respondError writes a service error as an error response, choosing the HTTP
+// status from the error kind and passing the stable error code through.
+// Internal errors are attached to the context so the request log shows the cause.
+func respondError(c *gin.Context, err error) {
+	var domainErr *services.Error
+	if !errors.As(err, &domainErr) {
+		_ = c.Error(err)
+		utils.ErrorResponse(c, http.StatusInternalServerError, services.CodeInternal, "internal server error")
+		return
+	}
+
+	status := errorStatus(domainErr)
+	if status >= http.StatusInternalServerError && domainErr.Cause != nil {
+		_ = c.Error(fmt.Errorf("%s: %w", domainErr.Message, domainErr.Cause))
+	}
+	utils.ValidationErrorResponse(c, status, domainErr.Code, domainErr.Message, domainErr.Details)
+}
+
+// respondBindingError writes a request binding failure, listing invalid fields when known
+func respondBindingError(c *gin.Context, err error) {
+	details := utils.BindingErrors(err)
+	if details == nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, utils.CodeInvalidInput, "Invalid input: "+err.Error())
+		return
+	}
+	utils.ValidationErrorResponse(c, http.StatusBadRequest, services.CodeValidation, "validation failed", details)
+}
+
+// errorStatus maps an error kind to its HTTP status code
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrValidation):
+		return http.StatusBadRequest
+	case errors.Is(err, services.ErrConflict):
+		return http.StatusConflict
+	case errors.Is(err, services.ErrUnauthorized):
+		return http.StatusUnauthorized
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"net/http"
+
+	"gmdb/services"
+)
+
+// Handlers bundles the HTTP handlers of every resource
+type Handlers struct {
+	Actors  *ActorHandler
+	Movies  *MovieHandler
+	Awards  *AwardHandler
+	Cast    *CastHandler
+	Search  *SearchHandler
+	Auth    *AuthHandler
+	APIKeys *APIKeyHandler
+	Health  *HealthHandler
+	// Metrics serves the Prometheus exposition format
+	Metrics http.Handler
+}
+
+// New creates all resource handlers on top of svc, plus the given probes and
+// metrics endpoint
+func New(svc *services.Services, health *HealthHandler, metrics http.Handler) *Handlers {
+	return &Handlers{
+		Actors:  NewActorHandler(svc.Actors),
+		Movies:  NewMovieHandler(svc.Movies),
+		Awards:  NewAwardHandler(svc.Awards),
+		Cast:    NewCastHandler(svc.Cast),
+		Search:  NewSearchHandler(svc.Search),
+		Auth:    NewAuthHandler(svc.Auth),
+		APIKeys: NewAPIKeyHandler(svc.APIKeys),
+		Health:  health,
+		Metrics: metrics,
+	}
+}
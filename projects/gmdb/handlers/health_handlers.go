@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"gmdb/buildinfo"
+	"gmdb/config"
+	"gmdb/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// readinessCheckTimeout bounds each dependency check of /readyz
+const readinessCheckTimeout = 2 * time.Second
+
+// ReadinessCheck reports whether one dependency can serve traffic
+type ReadinessCheck struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+// CheckResult is the outcome of one readiness check
+type CheckResult struct {
+	Name     string `json:"name"`
+	Status   string `json:"status"` // "ok" or "fail"
+	Error    string `json:"error,omitempty"`
+	Duration string `json:"duration"`
+}
+
+// ReadinessReport lists every readiness check
+type ReadinessReport struct {
+	Status string        `json:"status"` // "ready" or "not_ready"
+	Checks []CheckResult `json:"checks"`
+}
+
+// VersionResponse describes the running build
+type VersionResponse struct {
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	Environment string `json:"environment"`
+	Commit      string `json:"commit"`
+	BuildTime   string `json:"build_time"`
+	GoVersion   string `json:"go_version"`
+}
+
+// HealthHandler serves the liveness, readiness and build-info probes
+type HealthHandler struct {
+	app    config.AppConfig
+	checks []ReadinessCheck
+}
+
+// NewHealthHandler creates a probe handler; /readyz runs checks in order
+func NewHealthHandler(app config.AppConfig, checks []ReadinessCheck) *HealthHandler {
+	return &HealthHandler{app: app, checks: checks}
+}
+
+// Healthz reports that the process is alive; it never touches dependencies
+func (h *HealthHandler) Healthz(c *gin.Context) {
+	utils.SuccessResponse(c, http.StatusOK, "Service is alive", gin.H{"status": "ok"})
+}
+
+// Readyz reports whether every dependency is ready, answering 503 otherwise
+func (h *HealthHandler) Readyz(c *gin.Context) {
+	report := ReadinessReport{Status: "ready", Checks: make([]CheckResult, 0, len(h.checks))}
+
+	for _, check := range h.checks {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), readinessCheckTimeout)
+		start := time.Now()
+		err := check.Check(ctx)
+		cancel()
+
+		result := CheckResult{Name: check.Name, Status: "ok", Duration: time.Since(start).Round(time.Microsecond).String()}
+		if err != nil {
+			result.Status = "fail"
+			result.Error = err.Error()
+			report.Status = "not_ready"
+		}
+		report.Checks = append(report.Checks, result)
+	}
+
+	if report.Status != "ready" {
+		c.JSON(http.StatusServiceUnavailable, utils.Response{
+			Success: false,
+			Error:   "service is not ready",
+			Code:    utils.CodeNotReady,
+			Data:    report,
+		})
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "Service is ready", report)
+}
+
+// Version reports the application name, version and build metadata
+func (h *HealthHandler) Version(c *gin.Context) {
+	build := buildinfo.Get()
+	utils.SuccessResponse(c, http.StatusOK, "Version retrieved successfully", VersionResponse{
+		Name:        h.app.Name,
+		Version:     h.app.Version,
+		Environment: h.app.Environment,
+		Commit:      build.Commit,
+		BuildTime:   build.BuildTime,
+		GoVersion:   build.GoVersion,
+	})
+}
@@ -0,0 +1,110 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"gmdb/config"
+	"gmdb/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestProbes(t *testing.T) {
+	s := newTestServer(t)
+
+	s.mustDo(http.MethodGet, "/healthz", nil, http.StatusOK, nil)
+
+	var report handlers.ReadinessReport
+	s.mustDo(http.MethodGet, "/readyz", nil, http.StatusOK, &report)
+	if report.Status != "ready" || len(report.Checks) == 0 {
+		t.Fatalf("report = %+v", report)
+	}
+
+	var version handlers.VersionResponse
+	s.mustDo(http.MethodGet, "/version", nil, http.StatusOK, &version)
+	if version.Commit == "" || version.BuildTime == "" || version.GoVersion == "" {
+		t.Fatalf("version = %+v", version)
+	}
+}
+
+func TestReadyzReportsFailingChecks(t *testing.T) {
+	t.Parallel()
+
+	h := handlers.NewHealthHandler(config.AppConfig{Name: "GMDB", Version: "1.2.3"}, []handlers.ReadinessCheck{
+		{Name: "database", Check: func(context.Context) error { return nil }},
+		{Name: "migrations", Check: func(context.Context) error { return errors.New("1 pending migration(s)") }},
+	})
+	r := gin.New()
+	r.GET("/readyz", h.Readyz)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("status = %d", w.Code)
+	}
+
+	var body struct {
+		Code string                   `json:"code"`
+		Data handlers.ReadinessReport `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	checks := body.Data.Checks
+	if body.Code != "not_ready" || len(checks) != 2 || checks[0].Status != "ok" || checks[1].Error != "1 pending migration(s)" {
+		t.Fatalf("body = %+v", body)
+	}
+}
+
+func TestRequestID(t *testing.T) {
+	s := newTestServer(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+	req.Header.Set("X-Request-ID", "upstream-42")
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	if got := w.Header().Get("X-Request-ID"); got != "upstream-42" {
+		t.Fatalf("propagated request ID = %q", got)
+	}
+
+	// Missing or malformed IDs are replaced by a generated one
+	req = httptest.NewRequest(http.MethodGet, "/healthz", nil)
+	req.Header.Set("X-Request-ID", "bad id\nwith newline")
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	if got := w.Header().Get("X-Request-ID"); len(got) != 36 {
+		t.Fatalf("generated request ID = %q", got)
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	s := newTestServer(t)
+	id := s.createMovie("Heat", 1995, "Crime", 8.3)
+	s.createActor("Al Pacino", "")
+	s.mustDo(http.MethodGet, "/api/v1/movies/"+id, nil, http.StatusOK, nil)
+	s.do(http.MethodGet, "/no/such/route", nil)
+
+	w, _ := s.do(http.MethodGet, "/metrics", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d", w.Code)
+	}
+	body := w.Body.String()
+	for _, want := range []string{
+		`gmdb_http_requests_total{method="GET",route="/api/v1/movies/:id",status="200"} 1`,
+		`gmdb_http_requests_total{method="GET",route="unmatched",status="404"} 1`,
+		`gmdb_http_request_duration_seconds_count{method="POST",route="/api/v1/movies/"} 1`,
+		`gmdb_entities{type="movie"} 1`,
+		`gmdb_entities{type="actor"} 1`,
+		`gmdb_entities{type="award"} 0`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("metrics missing %s", want)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"gmdb/app"
+	"gmdb/config"
+	"gmdb/models"
+	"gmdb/repository"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// envelope mirrors utils.Response with Data left raw for per-test decoding
+type envelope struct {
+	Success bool            `json:"success"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data"`
+	Error   string          `json:"error"`
+	Code    string          `json:"code"`
+	Details []struct {
+		Field string `json:"field"`
+		Code  string `json:"code"`
+	} `json:"details"`
+	Meta *struct {
+		Limit      int    `json:"limit"`
+		NextCursor string `json:"next_cursor"`
+		HasMore    bool   `json:"has_more"`
+		Total      *int64 `json:"total"`
+	} `json:"meta"`
+}
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// testAuth signs the tokens of every test server
+var testAuth = config.AuthConfig{
+	SigningKey:      "handlers-test-signing-key-0123456789",
+	Issuer:          "gmdb-test",
+	AccessTokenTTL:  time.Minute,
+	RefreshTokenTTL: time.Hour,
+}
+
+// testServer is the full router backed by a fresh in-memory store
+type testServer struct {
+	t      *testing.T
+	app    *app.App
+	router *gin.Engine
+	token  string // bearer token sent by do; empty sends none
+	apiKey string // X-API-Key sent by do; empty sends none
+}
+
+// newTestServer starts an isolated app; servers share no state, so tests
+// using them can run in parallel
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+	return newTestServerWith(t, &config.Config{Features: map[string]bool{"search": true}})
+}
+
+// newTestServerWith is newTestServer with a custom config; requests are sent
+// as an admin unless the test switches with as
+func newTestServerWith(t *testing.T, cfg *config.Config) *testServer {
+	t.Helper()
+	t.Parallel()
+
+	if cfg.Auth.SigningKey == "" {
+		cfg.Auth = testAuth
+	}
+	a := app.NewWithRepositories(cfg, repository.NewMemoryRepositories())
+	s := &testServer{t: t, app: a, router: a.Router}
+	s.as(models.RoleAdmin)
+	return s
+}
+
+// as sends later requests with an access token for a new user with role;
+// an empty role sends them anonymously
+func (s *testServer) as(role models.Role) {
+	s.t.Helper()
+	if role == "" {
+		s.token = ""
+		return
+	}
+	token, _, err := s.app.Tokens.Issue(uuid.New(), role)
+	if err != nil {
+		s.t.Fatalf("issue %s token: %v", role, err)
+	}
+	s.token = token
+}
+
+// do sends a request with an optional JSON body and decodes the envelope
+func (s *testServer) do(method, path string, body any) (*httptest.ResponseRecorder, envelope) {
+	s.t.Helper()
+
+	var reader *bytes.Reader
+	switch b := body.(type) {
+	case nil:
+		reader = bytes.NewReader(nil)
+	case string:
+		reader = bytes.NewReader([]byte(b))
+	default:
+		payload, err := json.Marshal(b)
+		if err != nil {
+			s.t.Fatalf("marshal body: %v", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req := httptest.NewRequest(method, path, reader)
+	req.Header.Set("Content-Type", "application/json")
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+	if s.apiKey != "" {
+		req.Header.Set("X-API-Key", s.apiKey)
+	}
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	var env envelope
+	if strings.HasPrefix(w.Header().Get("Content-Type"), "application/json") {
+		if err := json.Unmarshal(w.Body.Bytes(), &env); err != nil {
+			s.t.Fatalf("%s %s: decode response %q: %v", method, path, w.Body.String(), err)
+		}
+	}
+	return w, env
+}
+
+// mustDo is do that fails the test unless the status matches
+func (s *testServer) mustDo(method, path string, body any, status int, out any) envelope {
+	s.t.Helper()
+	w, env := s.do(method, path, body)
+	if w.Code != status {
+		s.t.Fatalf("%s %s: status = %d, want %d; body %s", method, path, w.Code, status, w.Body.String())
+	}
+	if out != nil {
+		if err := json.Unmarshal(env.Data, out); err != nil {
+			s.t.Fatalf("%s %s: decode data: %v", method, path, err)
+		}
+	}
+	return env
+}
+
+// resource is the subset of every response DTO the tests need
+type resource struct {
+	ID string `json:"id"`
+}
+
+func (s *testServer) createMovie(title string, year int, genre string, rating float64) string {
+	s.t.Helper()
+	var movie resource
+	s.mustDo(http.MethodPost, "/api/v1/movies/", map[string]any{
+		"title": title, "year": year, "genre": genre, "director": "Director of " + title, "rating": rating,
+	}, http.StatusCreated, &movie)
+	return movie.ID
+}
+
+func (s *testServer) createActor(name, birthDate string) string {
+	s.t.Helper()
+	body := map[string]any{"name": name, "biography": name + " is an actor"}
+	if birthDate != "" {
+		body["birth_date"] = birthDate + "T00:00:00Z"
+	}
+	var actor resource
+	s.mustDo(http.MethodPost, "/api/v1/actors/", body, http.StatusCreated, &actor)
+	return actor.ID
+}
+
+// hasDetail reports whether env lists a field error with the given field and code
+func hasDetail(env envelope, field, code string) bool {
+	for _, d := range env.Details {
+		if d.Field == field && d.Code == code {
+			return true
+		}
+	}
+	return false
+}
@@ -3,25 +3,143 @@ package handlers
 import (
 	"net/http"
 
+	"gmdb/services"
+	"gmdb/utils"
+
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
-func HandleGetMovies(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{"message": "Hello, World!"})
+// MovieHandler serves the movie endpoints
+type MovieHandler struct {
+	service *services.MovieService
 }
 
-func HandleGetMovie(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{"message": "Hello, World!"})
+// NewMovieHandler creates a movie handler backed by service
+func NewMovieHandler(service *services.MovieService) *MovieHandler {
+	return &MovieHandler{service: service}
 }
 
-func HandleCreateMovie(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{"message": "Hello, World!"})
+// GetMovies retrieves one page of movies
+func (h *MovieHandler) GetMovies(c *gin.Context) {
+	// Parse filter, sort and pagination query parameters
+	query, err := parseListQuery(c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	// Call service
+	result, err := h.service.GetAllMovies(c.Request.Context(), query)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respondPage(c, "Movies retrieved successfully", result)
 }
 
-func HandleUpdateMovie(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{"message": "Hello, World!"})
+// GetMovie retrieves a single movie by ID
+func (h *MovieHandler) GetMovie(c *gin.Context) {
+	// Parse UUID from path
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, utils.CodeInvalidID, "Invalid movie ID")
+		return
+	}
+
+	// Call service
+	movie, err := h.service.GetMovie(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Movie retrieved successfully", movie)
 }
 
-func HandleDeleteMovie(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{"message": "Hello, World!"})
+// CreateMovie creates a new movie
+func (h *MovieHandler) CreateMovie(c *gin.Context) {
+	var req services.CreateMovieRequest
+
+	// Bind JSON input
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindingError(c, err)
+		return
+	}
+
+	// Call service (handles all business logic)
+	movie, err := h.service.CreateMovie(c.Request.Context(), req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Movie created successfully", movie)
+}
+
+// UpdateMovie updates an existing movie
+func (h *MovieHandler) UpdateMovie(c *gin.Context) {
+	// Parse UUID from path
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, utils.CodeInvalidID, "Invalid movie ID")
+		return
+	}
+
+	var req services.CreateMovieRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindingError(c, err)
+		return
+	}
+
+	// Call service
+	movie, err := h.service.UpdateMovie(c.Request.Context(), id, req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Movie updated successfully", movie)
+}
+
+// DeleteMovie soft deletes a movie
+func (h *MovieHandler) DeleteMovie(c *gin.Context) {
+	// Parse UUID from path
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, utils.CodeInvalidID, "Invalid movie ID")
+		return
+	}
+
+	// Call service
+	if err := h.service.DeleteMovie(c.Request.Context(), id); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Movie deleted successfully", nil)
+}
+
+// RestoreMovie undeletes a soft-deleted movie
+func (h *MovieHandler) RestoreMovie(c *gin.Context) {
+	// Parse UUID from path
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, utils.CodeInvalidID, "Invalid movie ID")
+		return
+	}
+
+	// Call service
+	movie, err := h.service.RestoreMovie(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Movie restored successfully", movie)
 }
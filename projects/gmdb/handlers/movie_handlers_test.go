@@ -0,0 +1,181 @@
+package handlers_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"gmdb/models"
+)
+
+func TestMovieCRUD(t *testing.T) {
+	s := newTestServer(t)
+	id := s.createMovie("The Matrix", 1999, "Sci-Fi", 8.74)
+
+	var movie struct {
+		Title  string  `json:"title"`
+		Year   int     `json:"year"`
+		Rating float64 `json:"rating"`
+	}
+	s.mustDo(http.MethodGet, "/api/v1/movies/"+id, nil, http.StatusOK, &movie)
+	if movie.Title != "The Matrix" || movie.Year != 1999 || movie.Rating != 8.7 {
+		t.Fatalf("got %+v", movie)
+	}
+
+	s.mustDo(http.MethodPut, "/api/v1/movies/"+id, map[string]any{"title": "  The Matrix Reloaded ", "year": 2003}, http.StatusOK, &movie)
+	if movie.Title != "The Matrix Reloaded" || movie.Year != 2003 {
+		t.Fatalf("update: %+v", movie)
+	}
+
+	s.mustDo(http.MethodDelete, "/api/v1/movies/"+id, nil, http.StatusOK, nil)
+	env := s.mustDo(http.MethodGet, "/api/v1/movies/"+id, nil, http.StatusNotFound, nil)
+	if env.Code != "movie_not_found" {
+		t.Fatalf("code = %q", env.Code)
+	}
+
+	// Restoring needs the delete scope and only applies to deleted movies
+	s.as(models.RoleEditor)
+	s.mustDo(http.MethodPost, "/api/v1/movies/"+id+"/restore", nil, http.StatusForbidden, nil)
+	s.as(models.RoleAdmin)
+	s.mustDo(http.MethodPost, "/api/v1/movies/"+id+"/restore", nil, http.StatusOK, &movie)
+	if movie.Title != "The Matrix Reloaded" {
+		t.Fatalf("restore: %+v", movie)
+	}
+	s.mustDo(http.MethodGet, "/api/v1/movies/"+id, nil, http.StatusOK, nil)
+	s.mustDo(http.MethodPost, "/api/v1/movies/"+id+"/restore", nil, http.StatusNotFound, nil)
+}
+
+func TestMovieValidation(t *testing.T) {
+	s := newTestServer(t)
+
+	env := s.mustDo(http.MethodPost, "/api/v1/movies/", map[string]any{"title": " ", "year": 1800, "rating": 11}, http.StatusBadRequest, nil)
+	if !hasDetail(env, "title", "required") || !hasDetail(env, "year", "out_of_range") || !hasDetail(env, "rating", "out_of_range") {
+		t.Fatalf("details = %+v", env.Details)
+	}
+
+	// A missing title is reported alongside every other violation
+	env = s.mustDo(http.MethodPost, "/api/v1/movies/", map[string]any{"year": 1800, "rating": 11}, http.StatusBadRequest, nil)
+	if !hasDetail(env, "title", "required") || !hasDetail(env, "year", "out_of_range") || !hasDetail(env, "rating", "out_of_range") {
+		t.Fatalf("missing title details = %+v", env.Details)
+	}
+
+	env = s.mustDo(http.MethodPost, "/api/v1/movies/", `{"title": "x", "year": "1999"}`, http.StatusBadRequest, nil)
+	if !hasDetail(env, "year", "invalid_type") {
+		t.Fatalf("type mismatch details = %+v", env.Details)
+	}
+}
+
+func TestMoviePagination(t *testing.T) {
+	s := newTestServer(t)
+	for i := 0; i < 5; i++ {
+		s.createMovie(fmt.Sprintf("Movie %d", i), 2000+i, "Drama", float64(i))
+	}
+
+	var seen []string
+	path := "/api/v1/movies/?limit=2&sort=-year&include_total=true"
+	for page := 0; path != ""; page++ {
+		var movies []struct {
+			Title string `json:"title"`
+		}
+		w, env := s.do(http.MethodGet, path, nil)
+		if w.Code != http.StatusOK {
+			t.Fatalf("page %d: status %d: %s", page, w.Code, w.Body.String())
+		}
+		s.mustDo(http.MethodGet, path, nil, http.StatusOK, &movies)
+		for _, m := range movies {
+			seen = append(seen, m.Title)
+		}
+		if env.Meta == nil || env.Meta.Total == nil || *env.Meta.Total != 5 || env.Meta.Limit != 2 {
+			t.Fatalf("page %d meta = %+v", page, env.Meta)
+		}
+
+		path = ""
+		if env.Meta.HasMore {
+			if !strings.Contains(w.Header().Get("Link"), `rel="next"`) {
+				t.Fatalf("page %d: missing Link header", page)
+			}
+			path = "/api/v1/movies/?limit=2&sort=-year&include_total=true&cursor=" + url.QueryEscape(env.Meta.NextCursor)
+		}
+	}
+
+	want := "Movie 4,Movie 3,Movie 2,Movie 1,Movie 0"
+	if got := strings.Join(seen, ","); got != want {
+		t.Fatalf("pages = %s, want %s", got, want)
+	}
+}
+
+func TestMoviePaginationErrors(t *testing.T) {
+	s := newTestServer(t)
+	s.createMovie("A", 2000, "Drama", 5)
+	s.createMovie("B", 2001, "Drama", 5)
+
+	_, env := s.do(http.MethodGet, "/api/v1/movies/?limit=1&sort=year", nil)
+	cursor := url.QueryEscape(env.Meta.NextCursor)
+
+	cases := map[string]string{
+		"/api/v1/movies/?limit=0":                             "limit",
+		"/api/v1/movies/?limit=abc":                           "limit",
+		"/api/v1/movies/?cursor=not-a-cursor":                 "cursor",
+		"/api/v1/movies/?include_total=maybe":                 "include_total",
+		"/api/v1/movies/?sort=title&cursor=" + cursor:         "cursor",
+		"/api/v1/movies/?sort=budget":                         "sort",
+		"/api/v1/movies/?studio=warner":                       "studio",
+		"/api/v1/movies/?year=nineteen":                       "year",
+		"/api/v1/movies/?rating_gte=high&sort=-rating,budget": "rating_gte",
+	}
+	for path, field := range cases {
+		env := s.mustDo(http.MethodGet, path, nil, http.StatusBadRequest, nil)
+		if env.Code != "validation_failed" || !strings.Contains(fmt.Sprint(env.Details), field) {
+			t.Errorf("%s: %+v", path, env)
+		}
+	}
+}
+
+func TestMovieFilters(t *testing.T) {
+	s := newTestServer(t)
+	s.createMovie("The Matrix", 1999, "Sci-Fi", 8.7)
+	s.createMovie("Heat", 1995, "Crime", 8.3)
+	s.createMovie("Arrival", 2016, "sci-fi", 7.9)
+
+	cases := map[string]string{
+		"genre=SCI-FI&sort=year":     "The Matrix,Arrival",
+		"year_gte=1996&sort=-rating": "The Matrix,Arrival",
+		"rating_lte=8.3&sort=title":  "Arrival,Heat",
+		"title_contains=%25":         "",
+		"title_contains=rix":         "The Matrix",
+		"year=1995":                  "Heat",
+	}
+	for query, want := range cases {
+		var movies []struct {
+			Title string `json:"title"`
+		}
+		s.mustDo(http.MethodGet, "/api/v1/movies/?"+query, nil, http.StatusOK, &movies)
+		titles := make([]string, len(movies))
+		for i, m := range movies {
+			titles[i] = m.Title
+		}
+		if got := strings.Join(titles, ","); got != want {
+			t.Errorf("%s: got %q, want %q", query, got, want)
+		}
+	}
+}
+
+func TestLegacyRoutesAreDeprecated(t *testing.T) {
+	s := newTestServer(t)
+	s.createMovie("Heat", 1995, "Crime", 8.3)
+
+	w, env := s.do(http.MethodGet, "/movies/", nil)
+	if w.Code != http.StatusOK || !env.Success {
+		t.Fatalf("legacy list: %d %s", w.Code, w.Body.String())
+	}
+	if w.Header().Get("Deprecation") == "" || w.Header().Get("Sunset") == "" {
+		t.Fatalf("missing deprecation headers: %v", w.Header())
+	}
+
+	w, _ = s.do(http.MethodGet, "/api/v1/movies/", nil)
+	if w.Header().Get("Deprecation") != "" {
+		t.Fatal("v1 route marked deprecated")
+	}
+}
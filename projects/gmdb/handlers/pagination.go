@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"gmdb/services"
+	"gmdb/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Query parameters that control paging and sorting rather than filtering
+var reservedListParams = map[string]bool{
+	"limit":         true,
+	"cursor":        true,
+	"include_total": true,
+	"sort":          true,
+}
+
+// parseListQuery reads pagination, sort and filter parameters from the query string;
+// every other parameter is treated as a filter and validated by the service
+func parseListQuery(c *gin.Context) (services.ListQuery, error) {
+	page, err := services.ParsePageRequest(c.Query("limit"), c.Query("cursor"), c.Query("include_total"))
+	if err != nil {
+		return services.ListQuery{}, err
+	}
+
+	filters := make(map[string]string)
+	for name, values := range c.Request.URL.Query() {
+		if !reservedListParams[name] && len(values) > 0 {
+			filters[name] = values[0]
+		}
+	}
+
+	return services.ListQuery{Page: page, Filters: filters, Sort: c.Query("sort")}, nil
+}
+
+// respondPage writes a page of results with pagination metadata and a Link header
+func respondPage[T any](c *gin.Context, message string, page *services.Page[T]) {
+	meta := &utils.PageMeta{
+		Limit:      page.Limit,
+		NextCursor: page.NextCursor,
+		HasMore:    page.NextCursor != "",
+		Total:      page.Total,
+	}
+
+	if page.NextCursor != "" {
+		next := *c.Request.URL
+		query := next.Query()
+		query.Set("cursor", page.NextCursor)
+		query.Set("limit", strconv.Itoa(page.Limit))
+		next.RawQuery = query.Encode()
+		c.Writer.Header().Add("Link", fmt.Sprintf("<%s>; rel=\"next\"", next.RequestURI()))
+	}
+
+	items := page.Items
+	if items == nil {
+		items = []T{}
+	}
+	utils.PageResponse(c, http.StatusOK, message, items, meta)
+}
@@ -0,0 +1,104 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"gmdb/config"
+	"gmdb/models"
+)
+
+func TestRateLimit(t *testing.T) {
+	s := newTestServerWith(t, &config.Config{
+		Features: map[string]bool{"search": true},
+		RateLimit: config.RateLimitConfig{
+			Enabled:           true,
+			RequestsPerSecond: 0.01, // one request per 100s: nothing refills during the test
+			Burst:             2,
+			Groups: map[string]config.RateLimitGroupConfig{
+				"auth": {RequestsPerSecond: 0.01, Burst: 1},
+			},
+		},
+	})
+	s.as(models.RoleReader)
+
+	for _, remaining := range []string{"1", "0"} {
+		w, _ := s.do(http.MethodGet, "/api/v1/actors/", nil)
+		if w.Code != http.StatusOK || w.Header().Get("RateLimit-Limit") != "2" || w.Header().Get("RateLimit-Remaining") != remaining {
+			t.Fatalf("allowed request: %d %v", w.Code, w.Header())
+		}
+	}
+	w, env := s.do(http.MethodGet, "/api/v1/actors/", nil)
+	if w.Code != http.StatusTooManyRequests || env.Code != "rate_limited" || w.Header().Get("Retry-After") != "100" ||
+		w.Header().Get("RateLimit-Reset") != "200" || w.Header().Get("RateLimit-Policy") != "2;w=200" {
+		t.Fatalf("throttled request: %d %+v %v", w.Code, env, w.Header())
+	}
+
+	// Legacy routes share the allowance, other groups and other clients have their own
+	s.mustDo(http.MethodGet, "/movies/", nil, http.StatusTooManyRequests, nil)
+	s.mustDo(http.MethodGet, "/api/v1/search?q=keanu", nil, http.StatusOK, nil)
+	s.as(models.RoleReader)
+	s.mustDo(http.MethodGet, "/api/v1/actors/", nil, http.StatusOK, nil)
+
+	// Anonymous callers are limited by IP, here with the auth group's override
+	s.as("")
+	login := map[string]any{"email": "ada@example.com", "password": "not the password"}
+	s.mustDo(http.MethodPost, "/api/v1/auth/login", login, http.StatusUnauthorized, nil)
+	s.mustDo(http.MethodPost, "/api/v1/auth/login", login, http.StatusTooManyRequests, nil)
+}
+
+// forwardedLimits lets each client log in once during a test
+var forwardedLimits = config.RateLimitConfig{Enabled: true, RequestsPerSecond: 0.01, Burst: 1}
+
+// loginForwardedFor attempts an anonymous login claiming to be forwarded for ip
+func (s *testServer) loginForwardedFor(ip string) int {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", strings.NewReader(`{"email": "ada@example.com", "password": "not the password"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Forwarded-For", ip)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w.Code
+}
+
+func TestRateLimitIgnoresSpoofedForwardedFor(t *testing.T) {
+	s := newTestServerWith(t, &config.Config{RateLimit: forwardedLimits})
+
+	// No proxy is trusted by default, so a new header does not get a new allowance
+	if code := s.loginForwardedFor("198.51.100.1"); code != http.StatusUnauthorized {
+		t.Fatalf("first login = %d", code)
+	}
+	for _, ip := range []string{"198.51.100.2", "198.51.100.3", "198.51.100.4"} {
+		if code := s.loginForwardedFor(ip); code != http.StatusTooManyRequests {
+			t.Fatalf("spoofed %s login = %d", ip, code)
+		}
+	}
+}
+
+func TestRateLimitTrustedProxy(t *testing.T) {
+	// httptest requests come from 192.0.2.1
+	s := newTestServerWith(t, &config.Config{
+		Server:    config.ServerConfig{TrustedProxies: []string{"192.0.2.0/24"}},
+		RateLimit: forwardedLimits,
+	})
+
+	for _, ip := range []string{"198.51.100.1", "198.51.100.2"} {
+		if code := s.loginForwardedFor(ip); code != http.StatusUnauthorized {
+			t.Fatalf("forwarded %s login = %d", ip, code)
+		}
+	}
+	if code := s.loginForwardedFor("198.51.100.1"); code != http.StatusTooManyRequests {
+		t.Fatalf("repeated forwarded login = %d", code)
+	}
+}
+
+func TestRateLimitDisabled(t *testing.T) {
+	s := newTestServer(t)
+	for range 5 {
+		w, _ := s.do(http.MethodGet, "/api/v1/actors/", nil)
+		if w.Code != http.StatusOK || w.Header().Get("RateLimit-Limit") != "" {
+			t.Fatalf("unthrottled request: %d %v", w.Code, w.Header())
+		}
+	}
+}
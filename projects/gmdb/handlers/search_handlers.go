@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"net/http"
+
+	"gmdb/services"
+	"gmdb/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SearchHandler serves the full-text search endpoint
+type SearchHandler struct {
+	service *services.SearchService
+}
+
+// NewSearchHandler creates a search handler backed by service
+func NewSearchHandler(service *services.SearchService) *SearchHandler {
+	return &SearchHandler{service: service}
+}
+
+// Search runs a full-text search across movies, actors and awards
+func (h *SearchHandler) Search(c *gin.Context) {
+	results, err := h.service.Search(c.Request.Context(), services.SearchRequest{
+		Query: c.Query("q"),
+		Types: c.Query("type"),
+		Limit: c.Query("limit"),
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Search completed successfully", results)
+}
@@ -0,0 +1,95 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"gmdb/config"
+)
+
+func TestSearch(t *testing.T) {
+	s := newTestServer(t)
+	s.createMovie("The Dark Knight", 2008, "Action", 9.0)
+	s.createMovie("Dark City", 1998, "Sci-Fi", 7.6)
+	s.createActor("Knight Rider", "")
+
+	var results []struct {
+		Type    string  `json:"type"`
+		Title   string  `json:"title"`
+		Snippet string  `json:"snippet"`
+		Rank    float64 `json:"rank"`
+	}
+	s.mustDo(http.MethodGet, "/api/v1/search?q=dark+knight", nil, http.StatusOK, &results)
+	if len(results) != 1 || results[0].Title != "The Dark Knight" {
+		t.Fatalf("results = %+v", results)
+	}
+	if !strings.Contains(results[0].Snippet, "<mark>") {
+		t.Fatalf("snippet not highlighted: %q", results[0].Snippet)
+	}
+
+	s.mustDo(http.MethodGet, "/api/v1/search?q=dark+-knight", nil, http.StatusOK, &results)
+	if len(results) != 1 || results[0].Title != "Dark City" {
+		t.Fatalf("exclusion results = %+v", results)
+	}
+
+	s.mustDo(http.MethodGet, "/api/v1/search?q=knight&type=actor", nil, http.StatusOK, &results)
+	if len(results) != 1 || results[0].Type != "actor" {
+		t.Fatalf("type filter results = %+v", results)
+	}
+
+	// Stored markup is escaped; only the match highlights are tags
+	s.createMovie("<script>alert(1)</script> Dark Water", 2005, "Horror", 5.6)
+	s.mustDo(http.MethodGet, "/api/v1/search?q=water", nil, http.StatusOK, &results)
+	if len(results) != 1 || !strings.HasPrefix(results[0].Snippet, "&lt;script&gt;alert(1)&lt;/script&gt; Dark <mark>Water</mark>") {
+		t.Fatalf("unescaped snippet: %+v", results)
+	}
+}
+
+func TestSearchValidation(t *testing.T) {
+	s := newTestServer(t)
+
+	env := s.mustDo(http.MethodGet, "/api/v1/search?type=studio&limit=500", nil, http.StatusBadRequest, nil)
+	if !hasDetail(env, "q", "required") || !hasDetail(env, "type", "invalid_choice") || !hasDetail(env, "limit", "out_of_range") {
+		t.Fatalf("details = %+v", env.Details)
+	}
+
+	// Search is only served under /api/v1
+	w, _ := s.do(http.MethodGet, "/search?q=dark", nil)
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("legacy search status = %d", w.Code)
+	}
+}
+
+func TestSearchFeatureDisabled(t *testing.T) {
+	s := newTestServerWith(t, &config.Config{Features: map[string]bool{"search": false}})
+
+	env := s.mustDo(http.MethodGet, "/api/v1/search?q=dark", nil, http.StatusNotFound, nil)
+	if env.Code != "feature_disabled" {
+		t.Fatalf("code = %q, want feature_disabled", env.Code)
+	}
+}
+
+func TestCORSPreflight(t *testing.T) {
+	s := newTestServerWith(t, &config.Config{Server: config.ServerConfig{
+		CORS: config.CORSConfig{AllowedOrigins: []string{"https://app.example"}},
+	}})
+
+	req := httptest.NewRequest(http.MethodOptions, "/api/v1/movies/", nil)
+	req.Header.Set("Origin", "https://app.example")
+	req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	if w.Code != http.StatusNoContent || w.Header().Get("Access-Control-Allow-Origin") != "https://app.example" {
+		t.Fatalf("preflight: status %d, headers %v", w.Code, w.Header())
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/movies/", nil)
+	req.Header.Set("Origin", "https://evil.example")
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Fatalf("unlisted origin allowed: %q", got)
+	}
+}
@@ -0,0 +1,88 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// GormLogger sends GORM's logs to slog.Default(), so queries run with a
+// request's context carry its request ID. Bound values are never logged.
+type GormLogger struct {
+	Level         logger.LogLevel
+	SlowThreshold time.Duration // 0 disables slow query warnings
+}
+
+var (
+	_ logger.Interface  = GormLogger{}
+	_ gorm.ParamsFilter = GormLogger{}
+)
+
+// LogMode implements logger.Interface
+func (l GormLogger) LogMode(level logger.LogLevel) logger.Interface {
+	l.Level = level
+	return l
+}
+
+func (l GormLogger) Info(ctx context.Context, msg string, data ...any) {
+	if l.Level >= logger.Info {
+		slog.InfoContext(ctx, fmt.Sprintf(msg, data...))
+	}
+}
+
+func (l GormLogger) Warn(ctx context.Context, msg string, data ...any) {
+	if l.Level >= logger.Warn {
+		slog.WarnContext(ctx, fmt.Sprintf(msg, data...))
+	}
+}
+
+func (l GormLogger) Error(ctx context.Context, msg string, data ...any) {
+	if l.Level >= logger.Error {
+		slog.ErrorContext(ctx, fmt.Sprintf(msg, data...))
+	}
+}
+
+// Trace logs failed queries at error, slow ones at warn and, at the info
+// level, every query
+func (l GormLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	if l.Level <= logger.Silent {
+		return
+	}
+	elapsed := time.Since(begin)
+	failed := err != nil && !errors.Is(err, gorm.ErrRecordNotFound)
+	slow := l.SlowThreshold > 0 && elapsed > l.SlowThreshold
+
+	var level slog.Level
+	var msg string
+	switch {
+	case failed && l.Level >= logger.Error:
+		level, msg = slog.LevelError, "query failed"
+	case slow && l.Level >= logger.Warn:
+		level, msg = slog.LevelWarn, "slow query"
+	case l.Level >= logger.Info:
+		level, msg = slog.LevelInfo, "query"
+	default:
+		return
+	}
+
+	sql, rows := fc()
+	attrs := []slog.Attr{
+		slog.String("sql", sql),
+		slog.Int64("rows", rows),
+		slog.Float64("duration_ms", float64(elapsed.Microseconds())/1000),
+	}
+	if failed {
+		attrs = append(attrs, slog.Any("error", err))
+	}
+	slog.LogAttrs(ctx, level, msg, attrs...)
+}
+
+// ParamsFilter keeps bound values out of logged SQL, which then shows $1, $2…
+func (l GormLogger) ParamsFilter(_ context.Context, sql string, _ ...any) (string, []any) {
+	return sql, nil
+}
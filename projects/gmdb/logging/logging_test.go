@@ -0,0 +1,87 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/gorm/logger"
+)
+
+func decodeLines(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var records []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var record map[string]any
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("decode %q: %v", line, err)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestLoggerAddsRequestIDAndRedacts(t *testing.T) {
+	var buf bytes.Buffer
+	var level slog.LevelVar
+	log := New(&buf, "json", &level)
+	ctx := WithRequestID(context.Background(), "req-1")
+
+	log.InfoContext(ctx, "login", slog.String("user", "ada"), slog.String("password", "hunter2"), slog.String("Authorization", "Bearer x"))
+	log.DebugContext(ctx, "hidden at info")
+	level.Set(slog.LevelDebug)
+	log.DebugContext(ctx, "shown at debug")
+
+	records := decodeLines(t, &buf)
+	if len(records) != 2 {
+		t.Fatalf("records = %v", records)
+	}
+	first := records[0]
+	if first["request_id"] != "req-1" || first["user"] != "ada" {
+		t.Fatalf("record = %v", first)
+	}
+	if first["password"] != Redacted || first["Authorization"] != Redacted {
+		t.Fatalf("sensitive values leaked: %v", first)
+	}
+	if strings.Contains(buf.String(), "hunter2") {
+		t.Fatal("password in output")
+	}
+}
+
+func TestRedactQuery(t *testing.T) {
+	got := RedactQuery(url.Values{"q": {"dark"}, "access_token": {"abc"}, "api_key": {"k"}})
+	want := "access_token=REDACTED&api_key=REDACTED&q=dark"
+	if got != want {
+		t.Fatalf("RedactQuery = %q, want %q", got, want)
+	}
+}
+
+func TestGormLoggerTrace(t *testing.T) {
+	var buf bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(New(&buf, "json", slog.LevelDebug))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	ctx := WithRequestID(context.Background(), "req-2")
+	sql := func() (string, int64) { return "SELECT * FROM movies WHERE id = $1", 1 }
+
+	warnOnly := GormLogger{Level: logger.Warn, SlowThreshold: 10 * time.Millisecond}
+	warnOnly.Trace(ctx, time.Now(), sql, nil)                           // fast: not logged
+	warnOnly.Trace(ctx, time.Now().Add(-50*time.Millisecond), sql, nil) // slow
+
+	records := decodeLines(t, &buf)
+	if len(records) != 1 || records[0]["msg"] != "slow query" || records[0]["request_id"] != "req-2" {
+		t.Fatalf("records = %v", records)
+	}
+	if records[0]["sql"] != "SELECT * FROM movies WHERE id = $1" {
+		t.Fatalf("sql = %v", records[0]["sql"])
+	}
+}
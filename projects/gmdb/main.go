@@ -1,18 +1,22 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"log/slog"
 	"os"
+	"os/signal"
+	"syscall"
 
+	"gmdb/app"
 	"gmdb/config"
-	"gmdb/handlers"
 	"gmdb/migrations"
-	"gmdb/routes"
-	"gmdb/services"
+	"gmdb/telemetry"
 
 	"github.com/gin-gonic/gin"
 	"github.com/spf13/cobra"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 )
 
 var configFile string
@@ -23,46 +27,61 @@ var rootCmd = &cobra.Command{
 	Long:  "A REST API for managing movies, actors, and awards built with Go and Gin.",
 	Run: func(cmd *cobra.Command, args []string) {
 		// Load configuration
-		if err := config.LoadConfig(configFile); err != nil {
-			log.Fatal("Failed to load config:", err)
-		}
-
-		// Connect to database
-		config.ConnectDB()
-
-		// Initialize services
-		db := config.GetDB()
-		actorService := services.NewActorService(db)
+		cfg := mustLoadConfig(config.Sections...)
 
-		// Initialize handlers with services
-		handlers.InitActorHandlers(actorService)
+		// Connect to database and refuse to serve an outdated schema
+		db, err := config.ConnectDB(cfg.Database)
+		if err != nil {
+			log.Fatal("Failed to connect to database:", err)
+		}
+		requireCurrentSchema(db)
 
 		// Set Gin mode based on environment
-		if config.GlobalConfig.App.Environment == "production" {
+		if cfg.App.Environment == "production" {
 			gin.SetMode(gin.ReleaseMode)
 		}
 
-		// Initialize Gin router
-		r := gin.Default()
+		// Export traces when telemetry is enabled
+		var opts []app.Option
+		var tracerProvider *sdktrace.TracerProvider
+		if cfg.Telemetry.Enabled {
+			tracerProvider, err = telemetry.NewTracerProvider(context.Background(), cfg.Telemetry, cfg.App)
+			if err != nil {
+				log.Fatal("Failed to set up tracing:", err)
+			}
+			opts = append(opts, app.WithTracerProvider(tracerProvider))
+		}
 
-		// Setup routes
-		routes.SetupRoutes(r)
+		// Wire repositories, services, handlers and routes
+		application := app.New(cfg, db, opts...)
+		if tracerProvider != nil {
+			// Flush buffered spans once requests have drained
+			application.OnShutdown(app.StageWorkers, "tracer provider", tracerProvider.Shutdown)
+		}
+
+		// Log through the app's logger, whose level follows config reloads
+		slog.SetDefault(application.Logger)
+		application.WatchConfig(configFile)
 
 		// Start server
-		serverAddr := fmt.Sprintf("%s:%d",
-			config.GlobalConfig.Server.Host,
-			config.GlobalConfig.Server.Port,
+		slog.Info("starting server",
+			slog.String("app", cfg.App.Name),
+			slog.String("version", cfg.App.Version),
+			slog.String("addr", application.Addr()),
 		)
 
-		log.Printf("Starting %s v%s server on %s",
-			config.GlobalConfig.App.Name,
-			config.GlobalConfig.App.Version,
-			serverAddr,
-		)
+		// SIGINT/SIGTERM start a graceful shutdown; a second signal kills the process
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		go func() {
+			<-ctx.Done()
+			stop()
+		}()
 
-		if err := r.Run(serverAddr); err != nil {
-			log.Fatal("Failed to start server:", err)
+		if err := application.Run(ctx); err != nil {
+			slog.Error("server stopped with error", slog.Any("error", err))
+			os.Exit(1)
 		}
+		slog.Info("server stopped")
 	},
 }
 
@@ -71,16 +90,18 @@ var seedCmd = &cobra.Command{
 	Short: "Seed the database with sample data",
 	Long:  "Populates the database with sample actors, movies, and awards for testing purposes.",
 	Run: func(cmd *cobra.Command, args []string) {
-		// Load configuration
-		if err := config.LoadConfig(configFile); err != nil {
-			log.Fatal("Failed to load config:", err)
-		}
+		// Load configuration; seeding only needs the database
+		cfg := mustLoadConfig(config.SectionDatabase)
 
-		// Connect to database (this also runs migrations)
-		config.ConnectDB()
+		// Connect to database; seeding needs the latest schema
+		db, err := config.ConnectDB(cfg.Database)
+		if err != nil {
+			log.Fatal("Failed to connect to database:", err)
+		}
+		requireCurrentSchema(db)
 
 		// Seed the database
-		if err := migrations.SeedData(config.GetDB()); err != nil {
+		if err := migrations.SeedData(db); err != nil {
 			log.Fatal("Failed to seed database:", err)
 		}
 
@@ -89,10 +110,14 @@ var seedCmd = &cobra.Command{
 }
 
 func init() {
-	rootCmd.PersistentFlags().StringVarP(&configFile, "config", "c", "gmdb.yaml", "config file path")
+	rootCmd.PersistentFlags().StringVarP(&configFile, "config", "c", "", "config file path (default "+config.DefaultConfigFile+" if present)")
 
 	// Add subcommands
 	rootCmd.AddCommand(seedCmd)
+	rootCmd.AddCommand(migrateCmd)
+	rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(userCmd)
+	rootCmd.AddCommand(apiKeyCmd)
 }
 
 func main() {
@@ -0,0 +1,50 @@
+package metrics
+
+import (
+	"errors"
+	"time"
+
+	"gmdb/utils"
+
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"gorm.io/gorm"
+)
+
+// startKey stores a statement's start time on its *gorm.DB instance
+const startKey = "metrics:start"
+
+// InstrumentDB times every GORM statement, counts failures and exports the
+// connection pool stats of sql.DB.Stats(). Call it once per database.
+func (m *Metrics) InstrumentDB(db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	m.Registry.MustRegister(collectors.NewDBStatsCollector(sqlDB, db.Name()))
+
+	return utils.RegisterGormHooks(db, "metrics",
+		func(string) func(*gorm.DB) { return m.startTimer },
+		m.observe,
+	)
+}
+
+func (m *Metrics) startTimer(db *gorm.DB) {
+	db.InstanceSet(startKey, time.Now())
+}
+
+func (m *Metrics) observe(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		value, ok := db.InstanceGet(startKey)
+		if !ok {
+			return
+		}
+		table := db.Statement.Table
+		if table == "" {
+			table = "unknown"
+		}
+		m.dbQueryDuration.WithLabelValues(operation, table).Observe(time.Since(value.(time.Time)).Seconds())
+		if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+			m.dbQueryErrors.WithLabelValues(operation, table).Inc()
+		}
+	}
+}
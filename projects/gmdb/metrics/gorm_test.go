@@ -0,0 +1,51 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"gmdb/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestInstrumentDB(t *testing.T) {
+	// DryRun builds statements and runs every callback without a server
+	conn, err := pgx.ParseConfig("host=localhost dbname=gmdb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: stdlib.OpenDB(*conn)}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := New()
+	if err := m.InstrumentDB(db); err != nil {
+		t.Fatal(err)
+	}
+
+	db.Create(&models.Movie{Title: "Heat"})
+	db.Find(&[]models.Actor{})
+	db.Find(&[]models.Actor{})
+
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := w.Body.String()
+	for _, want := range []string{
+		`gmdb_db_query_duration_seconds_count{operation="create",table="movies"} 1`,
+		`gmdb_db_query_duration_seconds_count{operation="query",table="actors"} 2`,
+		`go_sql_max_open_connections{db_name="postgres"}`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("metrics missing %s", want)
+		}
+	}
+}
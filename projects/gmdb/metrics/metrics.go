@@ -0,0 +1,163 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// namespace prefixes every gmdb metric
+const namespace = "gmdb"
+
+// unmatchedRoute labels requests no route matched, keeping label cardinality bounded
+const unmatchedRoute = "unmatched"
+
+// Metrics owns one Prometheus registry. Each App has its own, so several
+// instances (e.g. in tests) never collide on registration.
+type Metrics struct {
+	Registry *prometheus.Registry
+
+	httpRequests    *prometheus.CounterVec
+	httpDuration    *prometheus.HistogramVec
+	dbQueryDuration *prometheus.HistogramVec
+	dbQueryErrors   *prometheus.CounterVec
+}
+
+// New creates a registry with the HTTP and database metrics plus the Go
+// runtime and process collectors
+func New() *Metrics {
+	m := &Metrics{
+		Registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests by method, route template and status code.",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency by method and route template.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+		dbQueryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "db_query_duration_seconds",
+			Help:      "GORM statement latency by operation and table.",
+			Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		}, []string{"operation", "table"}),
+		dbQueryErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "db_query_errors_total",
+			Help:      "Failed GORM statements by operation and table; record not found is not an error.",
+		}, []string{"operation", "table"}),
+	}
+	m.Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpDuration,
+		m.dbQueryDuration,
+		m.dbQueryErrors,
+	)
+	return m
+}
+
+// Handler serves the registry in the Prometheus text format. A failing
+// collector (e.g. entity counts while the database is down) only drops its
+// own series; everything else is still served.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.Registry, promhttp.HandlerOpts{
+		Registry:      m.Registry,
+		ErrorHandling: promhttp.ContinueOnError,
+	})
+}
+
+// Middleware counts and times requests, labelled with gin's route template
+// (/api/v1/movies/:id) rather than the raw path
+func (m *Metrics) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		method := c.Request.Method
+		m.httpRequests.WithLabelValues(method, route, strconv.Itoa(c.Writer.Status())).Inc()
+		m.httpDuration.WithLabelValues(method, route).Observe(time.Since(start).Seconds())
+	}
+}
+
+// EntityCounter returns the current number of rows of one entity type
+type EntityCounter func(ctx context.Context) (int64, error)
+
+// RegisterEntityCounts exports gmdb_entities{type=...}, counted at most once
+// per EntityCountTTL however often the registry is scraped
+func (m *Metrics) RegisterEntityCounts(counters map[string]EntityCounter) {
+	m.Registry.MustRegister(&entityCollector{
+		counters: counters,
+		ttl:      EntityCountTTL,
+		cached:   make(map[string]entityCount),
+	})
+}
+
+// EntityCountTTL is how long entity counts are reused between scrapes
+const EntityCountTTL = 30 * time.Second
+
+// entityScrapeTimeout bounds the count queries of one scrape
+const entityScrapeTimeout = 5 * time.Second
+
+var entitiesDesc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "", "entities"),
+	"Live (not soft-deleted) rows by entity type.",
+	[]string{"type"}, nil,
+)
+
+type entityCollector struct {
+	counters map[string]EntityCounter
+	ttl      time.Duration
+
+	mu     sync.Mutex // also keeps concurrent scrapes from counting twice
+	cached map[string]entityCount
+}
+
+// entityCount is the last successful count of one entity
+type entityCount struct {
+	n  int64
+	at time.Time
+}
+
+func (c *entityCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- entitiesDesc
+}
+
+func (c *entityCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), entityScrapeTimeout)
+	defer cancel()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for entity, count := range c.counters {
+		cached, ok := c.cached[entity]
+		if !ok || time.Since(cached.at) >= c.ttl {
+			n, err := count(ctx)
+			if err != nil {
+				// Failures are not cached, so the next scrape tries again
+				ch <- prometheus.NewInvalidMetric(entitiesDesc, fmt.Errorf("count %s: %w", entity, err))
+				continue
+			}
+			cached = entityCount{n: n, at: time.Now()}
+			c.cached[entity] = cached
+		}
+		ch <- prometheus.MustNewConstMetric(entitiesDesc, prometheus.GaugeValue, float64(cached.n), entity)
+	}
+}
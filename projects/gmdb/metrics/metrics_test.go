@@ -0,0 +1,51 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestEntityCountFailureKeepsOtherMetrics(t *testing.T) {
+	m := New()
+	calls := 0
+	m.RegisterEntityCounts(map[string]EntityCounter{
+		"movie": func(context.Context) (int64, error) {
+			calls++
+			return 3, nil
+		},
+		"actor": func(context.Context) (int64, error) {
+			return 0, errors.New("database is down")
+		},
+	})
+	m.httpRequests.WithLabelValues("GET", "/ping", "200").Inc()
+
+	for range 2 {
+		w := httptest.NewRecorder()
+		m.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, body %s", w.Code, w.Body)
+		}
+		body := w.Body.String()
+		for _, want := range []string{
+			`gmdb_entities{type="movie"} 3`,
+			`gmdb_http_requests_total{method="GET",route="/ping",status="200"} 1`,
+			`go_goroutines`,
+		} {
+			if !strings.Contains(body, want) {
+				t.Errorf("metrics missing %s", want)
+			}
+		}
+		if strings.Contains(body, `type="actor"`) {
+			t.Error("failed count was served")
+		}
+	}
+
+	// The second scrape reused the cached count
+	if calls != 1 {
+		t.Errorf("movie counted %d times", calls)
+	}
+}
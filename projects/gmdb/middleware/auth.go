@@ -0,0 +1,118 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"gmdb/auth"
+	"gmdb/models"
+	"gmdb/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIKeyHeader carries the API keys of machine clients
+const APIKeyHeader = "X-API-Key"
+
+// APIKeyAuthenticator resolves an API key to its caller; errors for keys that
+// must be rejected match auth.ErrInvalidAPIKey
+type APIKeyAuthenticator func(ctx context.Context, key string) (auth.Principal, error)
+
+// Authenticate identifies the caller from an "Authorization: Bearer" access
+// token or an X-API-Key header and puts it on the request context. Requests
+// without credentials continue anonymously for RequireRole and RequireScope
+// to judge; invalid credentials are rejected with 401.
+func Authenticate(tokens *auth.Tokens, apiKeys APIKeyAuthenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		key := c.GetHeader(APIKeyHeader)
+		switch {
+		case header == "" && key == "":
+			c.Next()
+			return
+		case header != "" && key != "":
+			utils.ErrorResponse(c, http.StatusUnauthorized, utils.CodeUnauthorized, "send either Authorization or "+APIKeyHeader+", not both")
+			c.Abort()
+			return
+		case key != "":
+			authenticateAPIKey(c, apiKeys, key)
+			return
+		}
+
+		scheme, token, _ := strings.Cut(header, " ")
+		if !strings.EqualFold(scheme, "Bearer") || token == "" {
+			c.Header("WWW-Authenticate", `Bearer error="invalid_request"`)
+			utils.ErrorResponse(c, http.StatusUnauthorized, utils.CodeUnauthorized, "Authorization header must be \"Bearer <token>\"")
+			c.Abort()
+			return
+		}
+		principal, err := tokens.Verify(token)
+		if err != nil {
+			c.Header("WWW-Authenticate", `Bearer error="invalid_token"`)
+			utils.ErrorResponse(c, http.StatusUnauthorized, utils.CodeUnauthorized, "invalid or expired access token")
+			c.Abort()
+			return
+		}
+
+		c.Request = c.Request.WithContext(auth.WithPrincipal(c.Request.Context(), principal))
+		c.Next()
+	}
+}
+
+func authenticateAPIKey(c *gin.Context, apiKeys APIKeyAuthenticator, key string) {
+	principal, err := apiKeys(c.Request.Context(), key)
+	switch {
+	case errors.Is(err, auth.ErrInvalidAPIKey):
+		utils.ErrorResponse(c, http.StatusUnauthorized, utils.CodeUnauthorized, "invalid, expired or revoked API key")
+		c.Abort()
+	case err != nil:
+		_ = c.Error(err)
+		utils.ErrorResponse(c, http.StatusInternalServerError, utils.CodeInternal, "internal server error")
+		c.Abort()
+	default:
+		c.Request = c.Request.WithContext(auth.WithPrincipal(c.Request.Context(), principal))
+		c.Next()
+	}
+}
+
+// RequireScope answers 401 to anonymous callers and 403 to callers that do
+// not hold scope; see auth.Principal.Allows
+func RequireScope(scope models.Scope) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, ok := auth.PrincipalFrom(c.Request.Context())
+		if !ok {
+			c.Header("WWW-Authenticate", "Bearer")
+			utils.ErrorResponse(c, http.StatusUnauthorized, utils.CodeUnauthorized, "authentication required")
+			c.Abort()
+			return
+		}
+		if !principal.Allows(scope) {
+			utils.ErrorResponse(c, http.StatusForbidden, utils.CodeForbidden, "this action requires the "+string(scope)+" scope")
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireRole answers 401 to anonymous callers and 403 to callers whose role
+// does not include role. API keys hold no role, so they are always refused.
+func RequireRole(role models.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, ok := auth.PrincipalFrom(c.Request.Context())
+		if !ok {
+			c.Header("WWW-Authenticate", "Bearer")
+			utils.ErrorResponse(c, http.StatusUnauthorized, utils.CodeUnauthorized, "authentication required")
+			c.Abort()
+			return
+		}
+		if !principal.Role.Includes(role) {
+			utils.ErrorResponse(c, http.StatusForbidden, utils.CodeForbidden, "this action requires the "+string(role)+" role")
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"net/http"
+
+	"gmdb/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireFeature answers 404 while the named feature flag is off; enabled is
+// consulted per request so flags can be flipped at runtime
+func RequireFeature(feature string, enabled func(string) bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !enabled(feature) {
+			utils.ErrorResponse(c, http.StatusNotFound, utils.CodeFeatureDisabled, "this feature is disabled")
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"gmdb/auth"
+	"gmdb/ratelimit"
+	"gmdb/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimit throttles the clients of a route group, each with its own
+// allowance: API keys by key, users by user and anonymous callers by IP, so
+// it must run after Authenticate. limits is consulted per request so limits
+// can change at runtime; it returns false while rate limiting is off.
+//
+// Responses carry RateLimit-Limit, RateLimit-Remaining, RateLimit-Reset and
+// RateLimit-Policy; rejected requests get 429 with Retry-After. Should the
+// store fail, requests are let through rather than turned away.
+func RateLimit(group string, store ratelimit.Store, limits func(group string) (ratelimit.Limit, bool)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit, enabled := limits(group)
+		if !enabled {
+			c.Next()
+			return
+		}
+
+		result, err := store.Take(c.Request.Context(), group+":"+rateLimitClient(c), limit)
+		if err != nil {
+			_ = c.Error(fmt.Errorf("rate limit store: %w", err))
+			c.Next()
+			return
+		}
+
+		c.Header("RateLimit-Limit", strconv.Itoa(result.Limit))
+		c.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		c.Header("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.Reset)))
+		c.Header("RateLimit-Policy", fmt.Sprintf("%d;w=%d", limit.Burst, ceilSeconds(limit.Window())))
+		if !result.Allowed {
+			c.Header("Retry-After", strconv.Itoa(max(1, ceilSeconds(result.RetryAfter))))
+			utils.ErrorResponse(c, http.StatusTooManyRequests, utils.CodeRateLimited, "rate limit exceeded, retry later")
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// rateLimitClient identifies whose allowance a request takes from
+func rateLimitClient(c *gin.Context) string {
+	if principal, ok := auth.PrincipalFrom(c.Request.Context()); ok {
+		if principal.IsAPIKey() {
+			return "key:" + principal.APIKeyID.String()
+		}
+		return "user:" + principal.UserID.String()
+	}
+	return "ip:" + c.ClientIP()
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+
+	"gmdb/config"
+	"gmdb/migrations"
+
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+)
+
+var (
+	migrateSteps int
+	migrateDir   string
+)
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Manage database schema migrations",
+	Long:  "Applies, rolls back and inspects the versioned SQL migrations embedded in the binary.",
+}
+
+var migrateUpCmd = &cobra.Command{
+	Use:   "up",
+	Short: "Apply all pending migrations",
+	Run: func(cmd *cobra.Command, args []string) {
+		migrator := newMigrator()
+
+		applied, err := migrator.Up()
+		if err != nil {
+			log.Fatal("Failed to apply migrations:", err)
+		}
+		if len(applied) == 0 {
+			fmt.Println("Database schema is already up to date")
+			return
+		}
+		fmt.Printf("Applied %d migration(s)\n", len(applied))
+	},
+}
+
+var migrateDownCmd = &cobra.Command{
+	Use:   "down",
+	Short: "Roll back the most recent migrations",
+	Run: func(cmd *cobra.Command, args []string) {
+		if migrateSteps < 1 {
+			log.Fatal("--steps must be at least 1")
+		}
+		migrator := newMigrator()
+
+		reverted, err := migrator.Down(migrateSteps)
+		if err != nil {
+			log.Fatal("Failed to roll back migrations:", err)
+		}
+		fmt.Printf("Rolled back %d migration(s)\n", len(reverted))
+	},
+}
+
+var migrateStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show which migrations have been applied",
+	Run: func(cmd *cobra.Command, args []string) {
+		migrator := newMigrator()
+
+		statuses, err := migrator.Status()
+		if err != nil {
+			log.Fatal("Failed to read migration status:", err)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05 MST")
+			}
+			fmt.Fprintf(w, "%04d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+		}
+		w.Flush()
+	},
+}
+
+var migrateCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create an empty up/down migration pair",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		upPath, downPath, err := migrations.Create(migrateDir, args[0])
+		if err != nil {
+			log.Fatal("Failed to create migration:", err)
+		}
+		fmt.Printf("Created %s\nCreated %s\n", upPath, downPath)
+	},
+}
+
+func init() {
+	migrateDownCmd.Flags().IntVarP(&migrateSteps, "steps", "n", 1, "number of migrations to roll back")
+	migrateCreateCmd.Flags().StringVar(&migrateDir, "dir", migrations.SourceDir, "directory to write migration files to")
+
+	migrateCmd.AddCommand(migrateUpCmd, migrateDownCmd, migrateStatusCmd, migrateCreateCmd)
+}
+
+// newMigrator loads config, connects to the database and builds a migrator
+func newMigrator() *migrations.Migrator {
+	cfg := mustLoadConfig(config.SectionDatabase)
+	db, err := config.ConnectDB(cfg.Database)
+	if err != nil {
+		log.Fatal("Failed to connect to database:", err)
+	}
+
+	migrator, err := migrations.NewMigrator(db)
+	if err != nil {
+		log.Fatal("Failed to load migrations:", err)
+	}
+	return migrator
+}
+
+// requireCurrentSchema stops the process when migrations are pending
+func requireCurrentSchema(db *gorm.DB) {
+	migrator, err := migrations.NewMigrator(db)
+	if err != nil {
+		log.Fatal("Failed to load migrations:", err)
+	}
+
+	pending, err := migrator.Pending()
+	if err != nil {
+		log.Fatal("Failed to check migration status:", err)
+	}
+	if len(pending) > 0 {
+		log.Fatalf("Database schema is behind by %d migration(s) (next: %04d_%s); run `gmdb migrate up` first",
+			len(pending), pending[0].Version, pending[0].Name)
+	}
+}
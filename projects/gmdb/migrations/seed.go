@@ -1,7 +1,7 @@
 package migrations
 
 import (
-	"log"
+	"log/slog"
 	"time"
 
 	"gmdb/models"
@@ -12,7 +12,7 @@ import (
 
 // SeedData populates the database with sample data
 func SeedData(db *gorm.DB) error {
-	log.Println("Seeding database with sample data...")
+	slog.Info("seeding database with sample data")
 
 	// Check if data already exists
 	var actorCount int64
@@ -21,7 +21,7 @@ func SeedData(db *gorm.DB) error {
 	}
 
 	if actorCount > 0 {
-		log.Println("Database already seeded, skipping...")
+		slog.Info("database already seeded, skipping", slog.Int64("actors", actorCount))
 		return nil
 	}
 
@@ -90,7 +90,6 @@ func SeedData(db *gorm.DB) error {
 			Category:    "Best Actor",
 			Year:        1995,
 			ActorID:     &actors[0].ID, // Tom Hanks
-			MovieID:     &movies[0].ID, // Forrest Gump
 			Description: "Won for outstanding performance as Forrest Gump",
 		},
 		{
@@ -99,7 +98,6 @@ func SeedData(db *gorm.DB) error {
 			Category:    "Best Supporting Actor",
 			Year:        2009,
 			ActorID:     &actors[1].ID, // Heath Ledger
-			MovieID:     &movies[1].ID, // The Dark Knight
 			Description: "Posthumously won for his iconic portrayal of the Joker",
 		},
 		{
@@ -108,7 +106,6 @@ func SeedData(db *gorm.DB) error {
 			Category:    "Best Actor",
 			Year:        2001,
 			ActorID:     &actors[2].ID, // Russell Crowe
-			MovieID:     &movies[2].ID, // Gladiator
 			Description: "Won for his powerful performance as Maximus",
 		},
 	}
@@ -117,34 +114,29 @@ func SeedData(db *gorm.DB) error {
 	if err := db.Create(&actors).Error; err != nil {
 		return err
 	}
-	log.Printf("Created %d actors", len(actors))
+	slog.Info("seeded actors", slog.Int("count", len(actors)))
 
 	if err := db.Create(&movies).Error; err != nil {
 		return err
 	}
-	log.Printf("Created %d movies", len(movies))
+	slog.Info("seeded movies", slog.Int("count", len(movies)))
 
 	if err := db.Create(&awards).Error; err != nil {
 		return err
 	}
-	log.Printf("Created %d awards", len(awards))
+	slog.Info("seeded awards", slog.Int("count", len(awards)))
 
-	// Create many-to-many relationships (actor-movie associations)
-	// Tom Hanks in Forrest Gump
-	if err := db.Model(&actors[0]).Association("Movies").Append(&movies[0]); err != nil {
-		return err
+	// Create many-to-many relationships (actor-movie credits)
+	credits := []models.MovieActor{
+		{MovieID: movies[0].ID, ActorID: actors[0].ID, CharacterName: "Forrest Gump", BillingOrder: 1, CreditType: models.CreditLead},
+		{MovieID: movies[1].ID, ActorID: actors[1].ID, CharacterName: "The Joker", BillingOrder: 2, CreditType: models.CreditSupporting},
+		{MovieID: movies[2].ID, ActorID: actors[2].ID, CharacterName: "Maximus", BillingOrder: 1, CreditType: models.CreditLead},
 	}
-
-	// Heath Ledger in The Dark Knight
-	if err := db.Model(&actors[1]).Association("Movies").Append(&movies[1]); err != nil {
-		return err
-	}
-
-	// Russell Crowe in Gladiator
-	if err := db.Model(&actors[2]).Association("Movies").Append(&movies[2]); err != nil {
+	if err := db.Create(&credits).Error; err != nil {
 		return err
 	}
+	slog.Info("seeded movie credits", slog.Int("count", len(credits)))
 
-	log.Println("Database seeding completed successfully!")
+	slog.Info("database seeding completed")
 	return nil
 }
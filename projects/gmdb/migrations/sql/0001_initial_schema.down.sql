@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS movie_actors;
+DROP TABLE IF EXISTS awards;
+DROP TABLE IF EXISTS movies;
+DROP TABLE IF EXISTS actors;
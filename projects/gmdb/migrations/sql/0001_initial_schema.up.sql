@@ -0,0 +1,50 @@
+-- Initial schema, matching what AutoMigrate used to create.
+-- IF NOT EXISTS lets databases created by AutoMigrate adopt this migration.
+
+CREATE TABLE IF NOT EXISTS actors (
+    id         uuid PRIMARY KEY,
+    name       text NOT NULL,
+    birth_date timestamptz,
+    biography  text,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_actors_deleted_at ON actors (deleted_at);
+
+CREATE TABLE IF NOT EXISTS movies (
+    id          uuid PRIMARY KEY,
+    title       text NOT NULL,
+    year        bigint,
+    director    text,
+    genre       text,
+    description text,
+    rating      decimal(3,1),
+    created_at  timestamptz,
+    updated_at  timestamptz,
+    deleted_at  timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_movies_deleted_at ON movies (deleted_at);
+
+CREATE TABLE IF NOT EXISTS awards (
+    id          uuid PRIMARY KEY,
+    name        text NOT NULL,
+    category    text,
+    year        bigint,
+    movie_id    uuid,
+    actor_id    uuid,
+    description text,
+    created_at  timestamptz,
+    updated_at  timestamptz,
+    deleted_at  timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_awards_deleted_at ON awards (deleted_at);
+
+CREATE TABLE IF NOT EXISTS movie_actors (
+    movie_id uuid NOT NULL,
+    actor_id uuid NOT NULL,
+    PRIMARY KEY (movie_id, actor_id)
+);
+ALTER TABLE movie_actors ADD COLUMN IF NOT EXISTS character_name varchar(255);
+ALTER TABLE movie_actors ADD COLUMN IF NOT EXISTS billing_order bigint NOT NULL DEFAULT 0;
+ALTER TABLE movie_actors ADD COLUMN IF NOT EXISTS credit_type varchar(20) NOT NULL DEFAULT 'supporting';
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_awards_search_vector;
+ALTER TABLE awards DROP COLUMN IF EXISTS search_vector;
+
+DROP INDEX IF EXISTS idx_actors_search_vector;
+ALTER TABLE actors DROP COLUMN IF EXISTS search_vector;
+
+DROP INDEX IF EXISTS idx_movies_search_vector;
+ALTER TABLE movies DROP COLUMN IF EXISTS search_vector;
@@ -0,0 +1,21 @@
+-- Weighted tsvector columns and GIN indexes used by GET /api/v1/search.
+-- PostgreSQL generates the columns, so they stay out of the GORM models.
+
+ALTER TABLE movies ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+    setweight(to_tsvector('english', coalesce(director, '')), 'B') ||
+    setweight(to_tsvector('english', coalesce(description, '')), 'C')
+) STORED;
+CREATE INDEX IF NOT EXISTS idx_movies_search_vector ON movies USING GIN (search_vector);
+
+ALTER TABLE actors ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', coalesce(name, '')), 'A') ||
+    setweight(to_tsvector('english', coalesce(biography, '')), 'C')
+) STORED;
+CREATE INDEX IF NOT EXISTS idx_actors_search_vector ON actors USING GIN (search_vector);
+
+ALTER TABLE awards ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', coalesce(name, '')), 'A') ||
+    setweight(to_tsvector('english', coalesce(description, '')), 'C')
+) STORED;
+CREATE INDEX IF NOT EXISTS idx_awards_search_vector ON awards USING GIN (search_vector);
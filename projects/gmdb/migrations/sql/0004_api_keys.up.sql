@@ -0,0 +1,16 @@
+-- API keys for machine clients. Only the SHA-256 hash of a key is stored;
+-- scopes are space-separated, e.g. 'movies:read movies:write'.
+
+CREATE TABLE IF NOT EXISTS api_keys (
+    id           uuid PRIMARY KEY,
+    name         text NOT NULL,
+    prefix       text NOT NULL,
+    secret_hash  text NOT NULL,
+    scopes       text NOT NULL,
+    expires_at   timestamptz,
+    last_used_at timestamptz,
+    revoked_at   timestamptz,
+    created_at   timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_api_keys_prefix ON api_keys (prefix);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_api_keys_secret_hash ON api_keys (secret_hash);
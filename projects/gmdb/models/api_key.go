@@ -0,0 +1,92 @@
+package models
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Scope grants one action on one resource, e.g. movies:write. API keys carry
+// explicit scopes; users hold every scope their role includes.
+type Scope string
+
+// Scopes lists every scope; delete scopes also cover restoring
+var Scopes = []Scope{
+	"movies:read", "movies:write", "movies:delete",
+	"actors:read", "actors:write", "actors:delete",
+	"awards:read", "awards:write", "awards:delete",
+	"search:read",
+}
+
+// Valid reports whether s is a known scope
+func (s Scope) Valid() bool {
+	return slices.Contains(Scopes, s)
+}
+
+// Role is the least role whose users hold s: reader for read, editor for
+// write, admin for delete
+func (s Scope) Role() Role {
+	switch {
+	case strings.HasSuffix(string(s), ":read"):
+		return RoleReader
+	case strings.HasSuffix(string(s), ":write"):
+		return RoleEditor
+	default:
+		return RoleAdmin
+	}
+}
+
+// ScopeList is stored as one space-separated text column, like an OAuth scope
+type ScopeList []Scope
+
+// Value implements driver.Valuer
+func (l ScopeList) Value() (driver.Value, error) {
+	parts := make([]string, len(l))
+	for i, scope := range l {
+		parts[i] = string(scope)
+	}
+	return strings.Join(parts, " "), nil
+}
+
+// Scan implements sql.Scanner
+func (l *ScopeList) Scan(src any) error {
+	var text string
+	switch v := src.(type) {
+	case string:
+		text = v
+	case []byte:
+		text = string(v)
+	case nil:
+	default:
+		return fmt.Errorf("cannot scan %T into ScopeList", src)
+	}
+
+	*l = nil
+	for _, field := range strings.Fields(text) {
+		*l = append(*l, Scope(field))
+	}
+	return nil
+}
+
+// APIKey is a credential for machine clients. Only the SHA-256 hash of the
+// key is stored; Prefix is its non-secret start, safe to display.
+type APIKey struct {
+	ID         uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
+	Name       string     `json:"name" gorm:"not null"`
+	Prefix     string     `json:"prefix" gorm:"not null;uniqueIndex"`
+	SecretHash string     `json:"-" gorm:"not null;uniqueIndex"`
+	Scopes     ScopeList  `json:"scopes" gorm:"type:text;not null"`
+	ExpiresAt  *time.Time `json:"expires_at"` // nil never expires
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// Active reports whether the key can authenticate at now
+func (k *APIKey) Active(now time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || now.Before(*k.ExpiresAt))
+}
@@ -2,9 +2,36 @@ package models
 
 import "github.com/google/uuid"
 
+// CreditType describes how an actor is credited in a movie
+type CreditType string
+
+const (
+	CreditLead       CreditType = "lead"
+	CreditSupporting CreditType = "supporting"
+	CreditCameo      CreditType = "cameo"
+	CreditVoice      CreditType = "voice"
+)
+
+// CreditTypes lists every valid credit type
+var CreditTypes = []CreditType{CreditLead, CreditSupporting, CreditCameo, CreditVoice}
+
+// Valid reports whether the credit type is one of the known values
+func (t CreditType) Valid() bool {
+	for _, known := range CreditTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
 // MovieActor represents the join table for many-to-many relationship
-// GORM will create this table automatically with the many2many tag
+// Registered with SetupJoinTable so GORM preloads and association writes
+// use this model; the table itself comes from the SQL migrations
 type MovieActor struct {
-	MovieID uuid.UUID `gorm:"primaryKey;type:uuid"`
-	ActorID uuid.UUID `gorm:"primaryKey;type:uuid"`
+	MovieID       uuid.UUID  `gorm:"primaryKey;type:uuid"`
+	ActorID       uuid.UUID  `gorm:"primaryKey;type:uuid"`
+	CharacterName string     `gorm:"type:varchar(255)"`
+	BillingOrder  int        `gorm:"not null;default:0"` // 1 = top billing, 0 = unbilled
+	CreditType    CreditType `gorm:"type:varchar(20);not null;default:supporting"`
 }
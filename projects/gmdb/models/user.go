@@ -0,0 +1,52 @@
+package models
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Role grants access to the API; each role includes the ones before it
+type Role string
+
+const (
+	RoleReader Role = "reader" // read every resource
+	RoleEditor Role = "editor" // also create and update
+	RoleAdmin  Role = "admin"  // also delete and restore
+)
+
+// Roles lists every role from least to most privileged
+var Roles = []Role{RoleReader, RoleEditor, RoleAdmin}
+
+// Valid reports whether r is a known role
+func (r Role) Valid() bool {
+	return slices.Contains(Roles, r)
+}
+
+// Includes reports whether r grants everything required grants
+func (r Role) Includes(required Role) bool {
+	return r.Valid() && slices.Index(Roles, r) >= slices.Index(Roles, required)
+}
+
+type User struct {
+	ID           uuid.UUID      `json:"id" gorm:"type:uuid;primaryKey"`
+	Email        string         `json:"email" gorm:"not null"` // stored lower-case
+	PasswordHash string         `json:"-" gorm:"not null"`     // bcrypt
+	Role         Role           `json:"role" gorm:"type:text;not null"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	DeletedAt    gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+}
+
+// RefreshToken is one issued refresh token; only its SHA-256 hash is stored.
+// Tokens are single use: refreshing revokes the presented token.
+type RefreshToken struct {
+	ID        uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
+	UserID    uuid.UUID  `json:"user_id" gorm:"type:uuid;not null;index"`
+	TokenHash string     `json:"-" gorm:"not null;uniqueIndex"`
+	ExpiresAt time.Time  `json:"expires_at" gorm:"not null"`
+	RevokedAt *time.Time `json:"revoked_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
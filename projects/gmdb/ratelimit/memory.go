@@ -0,0 +1,67 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often MemoryStore drops the limiters of idle clients
+const sweepInterval = time.Minute
+
+// MemoryStore keeps allowances in process memory
+type MemoryStore struct {
+	algorithm Algorithm
+	now       func() time.Time
+
+	mu        sync.Mutex
+	clients   map[string]*client
+	lastSweep time.Time
+}
+
+type client struct {
+	limiter  Limiter
+	limit    Limit
+	lastSeen time.Time
+}
+
+// NewMemoryStore creates a store whose clients are limited by algorithm
+func NewMemoryStore(algorithm Algorithm) *MemoryStore {
+	return &MemoryStore{algorithm: algorithm, now: time.Now, clients: make(map[string]*client)}
+}
+
+// Take implements Store. A client whose limit changed, e.g. by a config
+// reload, starts over with a fresh allowance. An invalid limit fails with
+// ErrInvalidLimit.
+func (s *MemoryStore) Take(_ context.Context, key string, limit Limit) (Result, error) {
+	if err := limit.Validate(); err != nil {
+		return Result{}, err
+	}
+	now := s.now()
+
+	s.mu.Lock()
+	s.sweep(now)
+	c, ok := s.clients[key]
+	if !ok || c.limit != limit {
+		c = &client{limiter: s.algorithm(limit, now), limit: limit}
+		s.clients[key] = c
+	}
+	c.lastSeen = now
+	s.mu.Unlock()
+
+	return c.limiter.Take(now), nil
+}
+
+// sweep drops clients idle for longer than their window: their allowance is
+// full again, so a fresh limiter is equivalent
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+	s.lastSweep = now
+	for key, c := range s.clients {
+		if now.Sub(c.lastSeen) > c.limit.Window() {
+			delete(s.clients, key)
+		}
+	}
+}
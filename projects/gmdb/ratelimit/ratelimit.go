@@ -0,0 +1,163 @@
+// Package ratelimit throttles clients with a token bucket or a sliding
+// window, keeping their state in a swappable Store.
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// Limit allows Burst requests at once, refilled at Rate requests per second
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// ErrInvalidLimit is returned by stores for a limit that allows nothing
+var ErrInvalidLimit = errors.New("rate limit needs a positive rate and a burst of at least 1")
+
+// Validate reports a limit without a positive Rate or without a Burst of at
+// least 1
+func (l Limit) Validate() error {
+	if l.Rate <= 0 || l.Burst < 1 {
+		return fmt.Errorf("%w, got rate %g and burst %d", ErrInvalidLimit, l.Rate, l.Burst)
+	}
+	return nil
+}
+
+// Window is how long an exhausted allowance takes to refill completely; zero
+// for an invalid limit
+func (l Limit) Window() time.Duration {
+	if l.Validate() != nil {
+		return 0
+	}
+	return time.Duration(float64(l.Burst) / l.Rate * float64(time.Second))
+}
+
+// Result is the outcome of taking one request from an allowance
+type Result struct {
+	Allowed   bool
+	Limit     int           // the burst
+	Remaining int           // requests still allowed right now
+	Reset     time.Duration // until the allowance is full again
+	// RetryAfter is how long a rejected client should wait; zero when allowed
+	RetryAfter time.Duration
+}
+
+// Limiter is the allowance of one client
+type Limiter interface {
+	Take(now time.Time) Result
+}
+
+// Algorithm creates the limiter of a client first seen at now
+type Algorithm func(limit Limit, now time.Time) Limiter
+
+// Algorithms for MemoryStore
+var (
+	TokenBucketAlgorithm   Algorithm = func(l Limit, now time.Time) Limiter { return NewTokenBucket(l, now) }
+	SlidingWindowAlgorithm Algorithm = func(l Limit, now time.Time) Limiter { return NewSlidingWindow(l) }
+)
+
+// Store keeps the allowances of every client. MemoryStore serves a single
+// process; replicas that must share limits need a store backed by a shared
+// database such as Redis.
+type Store interface {
+	// Take takes one request from the allowance of key under limit
+	Take(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+// TokenBucket starts full with Burst tokens and refills continuously at Rate
+// tokens per second; each request takes one token
+type TokenBucket struct {
+	limit      Limit
+	tokens     float64
+	lastRefill time.Time
+	mutex      sync.Mutex
+}
+
+// NewTokenBucket creates a full bucket
+func NewTokenBucket(limit Limit, now time.Time) *TokenBucket {
+	return &TokenBucket{limit: limit, tokens: float64(limit.Burst), lastRefill: now}
+}
+
+// Take takes a token if one is available; an invalid limit rejects every
+// request
+func (tb *TokenBucket) Take(now time.Time) Result {
+	if tb.limit.Validate() != nil {
+		return Result{Limit: tb.limit.Burst}
+	}
+
+	tb.mutex.Lock()
+	defer tb.mutex.Unlock()
+
+	// Refill tokens
+	if elapsed := now.Sub(tb.lastRefill); elapsed > 0 {
+		tb.tokens = math.Min(float64(tb.limit.Burst), tb.tokens+elapsed.Seconds()*tb.limit.Rate)
+		tb.lastRefill = now
+	}
+
+	result := Result{Limit: tb.limit.Burst}
+	if tb.tokens >= 1 {
+		tb.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = tb.secondsUntil(1 - tb.tokens)
+	}
+	result.Remaining = int(tb.tokens)
+	result.Reset = tb.secondsUntil(float64(tb.limit.Burst) - tb.tokens)
+	return result
+}
+
+// secondsUntil is how long refilling the given number of tokens takes
+func (tb *TokenBucket) secondsUntil(tokens float64) time.Duration {
+	return time.Duration(tokens / tb.limit.Rate * float64(time.Second))
+}
+
+// SlidingWindow allows Burst requests within any Window of time
+type SlidingWindow struct {
+	limit    Limit
+	window   time.Duration
+	requests []time.Time
+	mutex    sync.Mutex
+}
+
+// NewSlidingWindow creates an empty window
+func NewSlidingWindow(limit Limit) *SlidingWindow {
+	return &SlidingWindow{limit: limit, window: limit.Window()}
+}
+
+// Take records the request if fewer than Burst were made within the window;
+// an invalid limit rejects every request
+func (sw *SlidingWindow) Take(now time.Time) Result {
+	if sw.limit.Validate() != nil {
+		return Result{Limit: sw.limit.Burst}
+	}
+
+	sw.mutex.Lock()
+	defer sw.mutex.Unlock()
+
+	// Remove old requests
+	cutoff := now.Add(-sw.window)
+	valid := sw.requests[:0]
+	for _, at := range sw.requests {
+		if at.After(cutoff) {
+			valid = append(valid, at)
+		}
+	}
+	sw.requests = valid
+
+	result := Result{Limit: sw.limit.Burst}
+	if len(sw.requests) < sw.limit.Burst {
+		sw.requests = append(sw.requests, now)
+		result.Allowed = true
+	} else {
+		result.RetryAfter = sw.requests[0].Add(sw.window).Sub(now)
+	}
+	result.Remaining = sw.limit.Burst - len(sw.requests)
+	result.Reset = sw.requests[len(sw.requests)-1].Add(sw.window).Sub(now)
+	return result
+}
@@ -0,0 +1,115 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+var start = time.Date(2026, time.October, 16, 12, 0, 0, 0, time.UTC)
+
+// takeAll takes n requests at now and counts the allowed ones
+func takeAll(l Limiter, now time.Time, n int) (allowed int, last Result) {
+	for range n {
+		last = l.Take(now)
+		if last.Allowed {
+			allowed++
+		}
+	}
+	return allowed, last
+}
+
+func TestTokenBucket(t *testing.T) {
+	bucket := NewTokenBucket(Limit{Rate: 2, Burst: 4}, start)
+
+	allowed, last := takeAll(bucket, start, 5)
+	if allowed != 4 || last.Allowed || last.Remaining != 0 {
+		t.Fatalf("burst: allowed %d, last %+v", allowed, last)
+	}
+	if last.RetryAfter != 500*time.Millisecond || last.Reset != 2*time.Second {
+		t.Fatalf("retry after %s, reset %s", last.RetryAfter, last.Reset)
+	}
+
+	// Refills continuously at the rate, never beyond the burst
+	if allowed, _ := takeAll(bucket, start.Add(time.Second), 3); allowed != 2 {
+		t.Fatalf("after 1s allowed %d, want 2", allowed)
+	}
+	if allowed, _ := takeAll(bucket, start.Add(time.Hour), 10); allowed != 4 {
+		t.Fatalf("after 1h allowed %d, want 4", allowed)
+	}
+}
+
+func TestSlidingWindow(t *testing.T) {
+	window := NewSlidingWindow(Limit{Rate: 1, Burst: 3}) // 3 requests per 3s
+
+	window.Take(start)
+	window.Take(start.Add(time.Second))
+	last := window.Take(start.Add(2 * time.Second))
+	if !last.Allowed || last.Remaining != 0 || last.Reset != 3*time.Second {
+		t.Fatalf("third request: %+v", last)
+	}
+	last = window.Take(start.Add(2 * time.Second))
+	if last.Allowed || last.RetryAfter != time.Second {
+		t.Fatalf("fourth request: %+v", last)
+	}
+
+	// The first request leaves the window after 3s
+	if !window.Take(start.Add(3 * time.Second)).Allowed {
+		t.Fatal("request after the window slid was rejected")
+	}
+}
+
+func TestInvalidLimit(t *testing.T) {
+	for _, limit := range []Limit{{Rate: 1, Burst: 0}, {Rate: 0, Burst: 3}, {Rate: -1, Burst: 3}} {
+		if limit.Window() != 0 {
+			t.Errorf("%+v: window %s, want 0", limit, limit.Window())
+		}
+		for name, algorithm := range map[string]Algorithm{"token bucket": TokenBucketAlgorithm, "sliding window": SlidingWindowAlgorithm} {
+			if allowed, _ := takeAll(algorithm(limit, start), start, 2); allowed != 0 {
+				t.Errorf("%s %+v: allowed %d, want 0", name, limit, allowed)
+			}
+		}
+		if _, err := NewMemoryStore(SlidingWindowAlgorithm).Take(context.Background(), "a", limit); !errors.Is(err, ErrInvalidLimit) {
+			t.Errorf("%+v: store err = %v, want ErrInvalidLimit", limit, err)
+		}
+	}
+}
+
+func TestMemoryStore(t *testing.T) {
+	store := NewMemoryStore(TokenBucketAlgorithm)
+	now := start
+	store.now = func() time.Time { return now }
+	ctx := context.Background()
+	limit := Limit{Rate: 1, Burst: 2}
+
+	take := func(key string, limit Limit) Result {
+		t.Helper()
+		result, err := store.Take(ctx, key, limit)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return result
+	}
+
+	take("a", limit)
+	take("a", limit)
+	if take("a", limit).Allowed {
+		t.Fatal("a exceeded its burst")
+	}
+	if !take("b", limit).Allowed {
+		t.Fatal("b shares a's allowance")
+	}
+
+	// A changed limit starts the client over
+	if result := take("a", Limit{Rate: 1, Burst: 5}); !result.Allowed || result.Remaining != 4 {
+		t.Fatalf("after limit change: %+v", result)
+	}
+
+	// Idle clients are swept once their allowance is full again
+	now = now.Add(sweepInterval)
+	take("c", limit)
+	if len(store.clients) != 1 {
+		t.Fatalf("%d clients after sweep, want 1", len(store.clients))
+	}
+}
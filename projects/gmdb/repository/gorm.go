@@ -0,0 +1,423 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gmdb/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// NewGormRepositories creates repositories backed by PostgreSQL through GORM
+func NewGormRepositories(db *gorm.DB) *Repositories {
+	return &Repositories{
+		Actors:        &gormActorRepository{db: db},
+		Movies:        &gormMovieRepository{db: db},
+		Awards:        &gormAwardRepository{db: db},
+		Search:        &gormSearchRepository{db: db},
+		Users:         &gormUserRepository{db: db},
+		RefreshTokens: &gormRefreshTokenRepository{db: db},
+		APIKeys:       &gormAPIKeyRepository{db: db},
+	}
+}
+
+type gormActorRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormActorRepository) Create(ctx context.Context, actor *models.Actor) error {
+	return gormError(r.db.WithContext(ctx).Create(actor).Error)
+}
+
+func (r *gormActorRepository) Get(ctx context.Context, id uuid.UUID) (*models.Actor, error) {
+	return gormGet[models.Actor](r.db.WithContext(ctx), id)
+}
+
+func (r *gormActorRepository) GetMany(ctx context.Context, ids []uuid.UUID) ([]models.Actor, error) {
+	return gormGetMany[models.Actor](r.db.WithContext(ctx), ids)
+}
+
+func (r *gormActorRepository) List(ctx context.Context, opts ListOptions) (*ListResult[models.Actor], error) {
+	return gormList(r.db.WithContext(ctx).Model(&models.Actor{}), ActorFields, opts)
+}
+
+func (r *gormActorRepository) Count(ctx context.Context) (int64, error) {
+	return gormCount[models.Actor](r.db.WithContext(ctx))
+}
+
+func (r *gormActorRepository) Update(ctx context.Context, actor *models.Actor) error {
+	return gormError(r.db.WithContext(ctx).Save(actor).Error)
+}
+
+func (r *gormActorRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return gormDelete[models.Actor](r.db.WithContext(ctx), id)
+}
+
+func (r *gormActorRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	return gormRestore[models.Actor](r.db.WithContext(ctx), id)
+}
+
+type gormMovieRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormMovieRepository) Create(ctx context.Context, movie *models.Movie) error {
+	return gormError(r.db.WithContext(ctx).Create(movie).Error)
+}
+
+func (r *gormMovieRepository) Get(ctx context.Context, id uuid.UUID) (*models.Movie, error) {
+	return gormGet[models.Movie](r.db.WithContext(ctx), id)
+}
+
+func (r *gormMovieRepository) GetMany(ctx context.Context, ids []uuid.UUID) ([]models.Movie, error) {
+	return gormGetMany[models.Movie](r.db.WithContext(ctx), ids)
+}
+
+func (r *gormMovieRepository) List(ctx context.Context, opts ListOptions) (*ListResult[models.Movie], error) {
+	return gormList(r.db.WithContext(ctx).Model(&models.Movie{}), MovieFields, opts)
+}
+
+func (r *gormMovieRepository) Count(ctx context.Context) (int64, error) {
+	return gormCount[models.Movie](r.db.WithContext(ctx))
+}
+
+func (r *gormMovieRepository) Update(ctx context.Context, movie *models.Movie) error {
+	return gormError(r.db.WithContext(ctx).Save(movie).Error)
+}
+
+func (r *gormMovieRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return gormDelete[models.Movie](r.db.WithContext(ctx), id)
+}
+
+func (r *gormMovieRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	return gormRestore[models.Movie](r.db.WithContext(ctx), id)
+}
+
+func (r *gormMovieRepository) UpsertCredit(ctx context.Context, credit *models.MovieActor) error {
+	return gormError(r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "movie_id"}, {Name: "actor_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"character_name", "billing_order", "credit_type"}),
+	}).Create(credit).Error)
+}
+
+func (r *gormMovieRepository) DeleteCredit(ctx context.Context, movieID, actorID uuid.UUID) error {
+	return gormError(r.db.WithContext(ctx).Where("movie_id = ? AND actor_id = ?", movieID, actorID).
+		Delete(&models.MovieActor{}).Error)
+}
+
+func (r *gormMovieRepository) ListCredits(ctx context.Context, movieID uuid.UUID) ([]models.MovieActor, error) {
+	var credits []models.MovieActor
+	err := r.db.WithContext(ctx).Where("movie_id = ?", movieID).
+		Order("billing_order = 0, billing_order ASC").
+		Find(&credits).Error
+	return credits, gormError(err)
+}
+
+func (r *gormMovieRepository) ListCreditsByActor(ctx context.Context, actorID uuid.UUID) ([]models.MovieActor, error) {
+	var credits []models.MovieActor
+	err := r.db.WithContext(ctx).Where("actor_id = ?", actorID).Find(&credits).Error
+	return credits, gormError(err)
+}
+
+type gormAwardRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormAwardRepository) Create(ctx context.Context, award *models.Award) error {
+	return gormError(r.db.WithContext(ctx).Create(award).Error)
+}
+
+func (r *gormAwardRepository) CreateMany(ctx context.Context, awards []models.Award) error {
+	return gormError(r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return tx.Create(&awards).Error
+	}))
+}
+
+func (r *gormAwardRepository) Get(ctx context.Context, id uuid.UUID) (*models.Award, error) {
+	return gormGet[models.Award](r.db.WithContext(ctx), id)
+}
+
+func (r *gormAwardRepository) List(ctx context.Context, opts ListOptions) (*ListResult[models.Award], error) {
+	return gormList(r.db.WithContext(ctx).Model(&models.Award{}), AwardFields, opts)
+}
+
+func (r *gormAwardRepository) Count(ctx context.Context) (int64, error) {
+	return gormCount[models.Award](r.db.WithContext(ctx))
+}
+
+func (r *gormAwardRepository) Update(ctx context.Context, award *models.Award) error {
+	return gormError(r.db.WithContext(ctx).Save(award).Error)
+}
+
+func (r *gormAwardRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return gormDelete[models.Award](r.db.WithContext(ctx), id)
+}
+
+func (r *gormAwardRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	return gormRestore[models.Award](r.db.WithContext(ctx), id)
+}
+
+type gormUserRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormUserRepository) Create(ctx context.Context, user *models.User) error {
+	return gormError(r.db.WithContext(ctx).Create(user).Error)
+}
+
+func (r *gormUserRepository) Get(ctx context.Context, id uuid.UUID) (*models.User, error) {
+	return gormGet[models.User](r.db.WithContext(ctx), id)
+}
+
+func (r *gormUserRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
+	var user models.User
+	if err := r.db.WithContext(ctx).First(&user, "email = ?", email).Error; err != nil {
+		return nil, gormError(err)
+	}
+	return &user, nil
+}
+
+type gormRefreshTokenRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormRefreshTokenRepository) Create(ctx context.Context, token *models.RefreshToken) error {
+	return gormError(r.db.WithContext(ctx).Create(token).Error)
+}
+
+func (r *gormRefreshTokenRepository) Consume(ctx context.Context, hash string, now time.Time) (*models.RefreshToken, error) {
+	// A single conditional UPDATE makes concurrent refreshes race safely
+	var tokens []models.RefreshToken
+	err := r.db.WithContext(ctx).Model(&tokens).Clauses(clause.Returning{}).
+		Where("token_hash = ? AND revoked_at IS NULL AND expires_at > ?", hash, now).
+		Update("revoked_at", now).Error
+	if err != nil {
+		return nil, gormError(err)
+	}
+	if len(tokens) == 0 {
+		return nil, ErrNotFound
+	}
+	return &tokens[0], nil
+}
+
+type gormAPIKeyRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormAPIKeyRepository) Create(ctx context.Context, key *models.APIKey) error {
+	return gormError(r.db.WithContext(ctx).Create(key).Error)
+}
+
+func (r *gormAPIKeyRepository) List(ctx context.Context) ([]models.APIKey, error) {
+	var keys []models.APIKey
+	return keys, gormError(r.db.WithContext(ctx).Order("created_at DESC, id DESC").Find(&keys).Error)
+}
+
+func (r *gormAPIKeyRepository) Get(ctx context.Context, id uuid.UUID) (*models.APIKey, error) {
+	return gormGet[models.APIKey](r.db.WithContext(ctx), id)
+}
+
+func (r *gormAPIKeyRepository) GetByPrefix(ctx context.Context, prefix string) (*models.APIKey, error) {
+	var key models.APIKey
+	if err := r.db.WithContext(ctx).First(&key, "prefix = ?", prefix).Error; err != nil {
+		return nil, gormError(err)
+	}
+	return &key, nil
+}
+
+func (r *gormAPIKeyRepository) GetBySecretHash(ctx context.Context, hash string) (*models.APIKey, error) {
+	var key models.APIKey
+	if err := r.db.WithContext(ctx).First(&key, "secret_hash = ?", hash).Error; err != nil {
+		return nil, gormError(err)
+	}
+	return &key, nil
+}
+
+func (r *gormAPIKeyRepository) Revoke(ctx context.Context, id uuid.UUID, at time.Time) error {
+	result := r.db.WithContext(ctx).Model(&models.APIKey{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", at)
+	if result.Error != nil {
+		return gormError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *gormAPIKeyRepository) MarkUsed(ctx context.Context, id uuid.UUID, at time.Time) error {
+	return gormError(r.db.WithContext(ctx).Model(&models.APIKey{}).
+		Where("id = ?", id).
+		Update("last_used_at", at).Error)
+}
+
+// gormError translates GORM errors into repository errors, keeping the cause
+func gormError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return ErrNotFound
+	case errors.Is(err, gorm.ErrDuplicatedKey), errors.Is(err, gorm.ErrForeignKeyViolated):
+		return fmt.Errorf("%w: %w", ErrConflict, err)
+	default:
+		return err
+	}
+}
+
+func gormGet[M any](db *gorm.DB, id uuid.UUID) (*M, error) {
+	var row M
+	if err := db.First(&row, "id = ?", id).Error; err != nil {
+		return nil, gormError(err)
+	}
+	return &row, nil
+}
+
+func gormGetMany[M any](db *gorm.DB, ids []uuid.UUID) ([]M, error) {
+	var rows []M
+	if len(ids) == 0 {
+		return rows, nil
+	}
+	return rows, gormError(db.Where("id IN ?", ids).Find(&rows).Error)
+}
+
+func gormCount[M any](db *gorm.DB) (int64, error) {
+	var count int64
+	return count, gormError(db.Model(new(M)).Count(&count).Error)
+}
+
+func gormDelete[M any](db *gorm.DB, id uuid.UUID) error {
+	result := db.Delete(new(M), "id = ?", id)
+	if result.Error != nil {
+		return gormError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// gormRestore clears deleted_at on a soft-deleted row
+func gormRestore[M any](db *gorm.DB, id uuid.UUID) error {
+	result := db.Unscoped().Model(new(M)).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return gormError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// gormList runs a filtered keyset page; only allow-listed column expressions reach SQL
+func gormList[M any](query *gorm.DB, fields map[string]Field[M], opts ListOptions) (*ListResult[M], error) {
+	for _, filter := range opts.Filters {
+		field, ok := fields[filter.Field]
+		if !ok {
+			return nil, fmt.Errorf("unknown filter field %q", filter.Field)
+		}
+		condition, value, err := gormCondition(field.Column, filter)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where(condition, value)
+	}
+
+	result := &ListResult[M]{}
+	if opts.IncludeTotal {
+		var count int64
+		if err := query.Session(&gorm.Session{}).Count(&count).Error; err != nil {
+			return nil, gormError(err)
+		}
+		result.Total = &count
+	}
+
+	exprs := make([]string, len(opts.Sort))
+	order := make([]string, 0, len(opts.Sort)+1)
+	for i, key := range opts.Sort {
+		field, ok := fields[key.Field]
+		if !ok {
+			return nil, fmt.Errorf("unknown sort field %q", key.Field)
+		}
+		exprs[i] = field.sortExpr()
+		if key.Desc {
+			order = append(order, exprs[i]+" DESC")
+		} else {
+			order = append(order, exprs[i]+" ASC")
+		}
+	}
+	order = append(order, "id ASC")
+
+	if opts.After != nil {
+		condition, args := gormKeyset(exprs, opts.Sort, opts.After)
+		query = query.Where(condition, args...)
+	}
+
+	// Fetch one extra row to learn whether another page exists
+	var rows []M
+	if err := query.Order(strings.Join(order, ", ")).Limit(opts.Limit + 1).Find(&rows).Error; err != nil {
+		return nil, gormError(err)
+	}
+	if len(rows) > opts.Limit {
+		rows = rows[:opts.Limit]
+		result.HasMore = true
+	}
+	result.Items = rows
+	return result, nil
+}
+
+func gormCondition(column string, filter Filter) (string, any, error) {
+	switch filter.Op {
+	case OpEq:
+		return column + " = ?", filter.Value, nil
+	case OpEqFold:
+		return "LOWER(" + column + ") = LOWER(?)", filter.Value, nil
+	case OpContains:
+		escaped := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(fmt.Sprint(filter.Value))
+		return column + " ILIKE ?", "%" + escaped + "%", nil
+	case OpLt:
+		return column + " < ?", filter.Value, nil
+	case OpGte:
+		return column + " >= ?", filter.Value, nil
+	case OpLte:
+		return column + " <= ?", filter.Value, nil
+	default:
+		return "", nil, fmt.Errorf("unknown filter op %q", filter.Op)
+	}
+}
+
+// gormKeyset builds "rows after the cursor" for mixed-direction keys:
+// (k1 > v1) OR (k1 = v1 AND k2 < v2) OR ... OR (k1 = v1 AND ... AND id > last_id)
+func gormKeyset(exprs []string, keys []SortKey, after *Cursor) (string, []any) {
+	var terms []string
+	var args []any
+	for i := 0; i <= len(keys); i++ {
+		var parts []string
+		for j := 0; j < i; j++ {
+			parts = append(parts, exprs[j]+" = ?")
+			args = append(args, after.Values[j])
+		}
+		if i < len(keys) {
+			op := " > ?"
+			if keys[i].Desc {
+				op = " < ?"
+			}
+			parts = append(parts, exprs[i]+op)
+			args = append(args, after.Values[i])
+		} else {
+			parts = append(parts, "id > ?")
+			args = append(args, after.ID)
+		}
+		terms = append(terms, "("+strings.Join(parts, " AND ")+")")
+	}
+	return strings.Join(terms, " OR "), args
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// searchQueries holds one ranked full-text query per searchable type. Each
+// reads the generated search_vector column created by migration 0002.
+var searchQueries = map[string]string{
+	"movie": `SELECT 'movie' AS type, m.id, m.title AS title,
+		ts_headline('english', concat_ws(' ', m.title, m.director, m.description), q.query, @headline) AS snippet,
+		ts_rank(m.search_vector, q.query) AS rank
+		FROM movies m, q
+		WHERE m.deleted_at IS NULL AND m.search_vector @@ q.query`,
+	"actor": `SELECT 'actor' AS type, a.id, a.name AS title,
+		ts_headline('english', concat_ws(' ', a.name, a.biography), q.query, @headline) AS snippet,
+		ts_rank(a.search_vector, q.query) AS rank
+		FROM actors a, q
+		WHERE a.deleted_at IS NULL AND a.search_vector @@ q.query`,
+	"award": `SELECT 'award' AS type, w.id, w.name AS title,
+		ts_headline('english', concat_ws(' ', w.name, w.description), q.query, @headline) AS snippet,
+		ts_rank(w.search_vector, q.query) AS rank
+		FROM awards w, q
+		WHERE w.deleted_at IS NULL AND w.search_vector @@ q.query`,
+}
+
+// headlineOptions delimits matches for markSnippet and keeps snippets short
+const headlineOptions = "StartSel=" + matchStart + ", StopSel=" + matchStop + ", MaxWords=35, MinWords=15, MaxFragments=2"
+
+type gormSearchRepository struct {
+	db *gorm.DB
+}
+
+// Search runs websearch_to_tsquery over the requested types, best matches first
+func (r *gormSearchRepository) Search(ctx context.Context, query string, types []string, limit int) ([]SearchHit, error) {
+	// Only allow-listed SQL is assembled; the query text is a bound argument
+	parts := make([]string, 0, len(types))
+	for _, t := range types {
+		if sql, ok := searchQueries[t]; ok {
+			parts = append(parts, "("+sql+")")
+		}
+	}
+	if len(parts) == 0 {
+		return nil, nil
+	}
+
+	sql := "WITH q AS (SELECT websearch_to_tsquery('english', @query) AS query) " +
+		strings.Join(parts, " UNION ALL ") +
+		" ORDER BY rank DESC, title ASC LIMIT @limit"
+
+	var hits []SearchHit
+	err := r.db.WithContext(ctx).Raw(sql, map[string]interface{}{
+		"query":    query,
+		"headline": headlineOptions,
+		"limit":    limit,
+	}).Scan(&hits).Error
+	for i := range hits {
+		hits[i].Snippet = markSnippet(hits[i].Snippet)
+	}
+	return hits, gormError(err)
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestGormCondition(t *testing.T) {
+	cases := []struct {
+		filter    Filter
+		condition string
+		value     any
+	}{
+		{Filter{Op: OpEq, Value: 1999}, "year = ?", 1999},
+		{Filter{Op: OpEqFold, Value: "Drama"}, "LOWER(year) = LOWER(?)", "Drama"},
+		{Filter{Op: OpContains, Value: `50%_off\`}, "year ILIKE ?", `%50\%\_off\\%`},
+		{Filter{Op: OpLt, Value: 5}, "year < ?", 5},
+		{Filter{Op: OpGte, Value: 5}, "year >= ?", 5},
+		{Filter{Op: OpLte, Value: 5}, "year <= ?", 5},
+	}
+	for _, tc := range cases {
+		condition, value, err := gormCondition("year", tc.filter)
+		if err != nil || condition != tc.condition || value != tc.value {
+			t.Errorf("%s: got (%q, %v, %v), want (%q, %v)", tc.filter.Op, condition, value, err, tc.condition, tc.value)
+		}
+	}
+
+	if _, _, err := gormCondition("year", Filter{Op: "like"}); err == nil {
+		t.Error("unknown op: want error")
+	}
+}
+
+func TestGormKeyset(t *testing.T) {
+	id := uuid.New()
+	after := &Cursor{Values: []any{8.5, "Heat"}, ID: id}
+	keys := []SortKey{{Field: "rating", Desc: true}, {Field: "title"}}
+
+	condition, args := gormKeyset([]string{"rating", "title"}, keys, after)
+
+	wantCondition := "(rating < ?) OR (rating = ? AND title > ?) OR (rating = ? AND title = ? AND id > ?)"
+	if condition != wantCondition {
+		t.Errorf("condition = %q", condition)
+	}
+	wantArgs := []any{8.5, 8.5, "Heat", 8.5, "Heat", id}
+	if !reflect.DeepEqual(args, wantArgs) {
+		t.Errorf("args = %v", args)
+	}
+}
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"time"
+
+	"gmdb/models"
+
+	"github.com/google/uuid"
+)
+
+// Op is a filter comparison
+type Op string
+
+const (
+	OpEq       Op = "eq"
+	OpEqFold   Op = "eq_fold"  // case-insensitive equality
+	OpContains Op = "contains" // case-insensitive substring
+	OpLt       Op = "lt"
+	OpGte      Op = "gte"
+	OpLte      Op = "lte"
+)
+
+// Filter restricts a list to rows whose field compares to Value
+type Filter struct {
+	Field string
+	Op    Op
+	Value any
+}
+
+// SortKey orders a list by one field
+type SortKey struct {
+	Field string
+	Desc  bool
+}
+
+// Cursor marks the last row of the previous page
+type Cursor struct {
+	Values []any // the row's values for each sort key
+	ID     uuid.UUID
+}
+
+// ListOptions selects one keyset page; rows are ordered by Sort and then by ID
+type ListOptions struct {
+	Filters      []Filter
+	Sort         []SortKey
+	After        *Cursor
+	Limit        int
+	IncludeTotal bool
+}
+
+// ListResult is one page of rows
+type ListResult[M any] struct {
+	Items   []M
+	HasMore bool
+	Total   *int64 // only set when IncludeTotal was requested
+}
+
+// Field describes a filterable or sortable model field for both implementations
+type Field[M any] struct {
+	Column   string       // column used in WHERE conditions
+	SortExpr string       // ORDER BY and keyset expression; defaults to Column
+	Value    func(M) any  // Go value matching SortExpr, never nil
+	IsNull   func(M) bool // optional; NULL columns never match a filter
+}
+
+func (f Field[M]) sortExpr() string {
+	if f.SortExpr != "" {
+		return f.SortExpr
+	}
+	return f.Column
+}
+
+// ActorFields are the actor fields lists can filter and sort on
+var ActorFields = map[string]Field[models.Actor]{
+	"name": {Column: "name", Value: func(a models.Actor) any { return a.Name }},
+	// Actors without a birth date sort as the earliest possible date
+	"birth_date": {
+		Column:   "birth_date",
+		SortExpr: "COALESCE(birth_date, '0001-01-01T00:00:00Z')",
+		Value: func(a models.Actor) any {
+			if a.BirthDate == nil {
+				return time.Time{}
+			}
+			return a.BirthDate.UTC()
+		},
+		IsNull: func(a models.Actor) bool { return a.BirthDate == nil },
+	},
+	"created_at": {Column: "created_at", Value: func(a models.Actor) any { return a.CreatedAt }},
+}
+
+// MovieFields are the movie fields lists can filter and sort on
+var MovieFields = map[string]Field[models.Movie]{
+	"title":      {Column: "title", Value: func(m models.Movie) any { return m.Title }},
+	"director":   {Column: "director", Value: func(m models.Movie) any { return m.Director }},
+	"genre":      {Column: "genre", Value: func(m models.Movie) any { return m.Genre }},
+	"year":       {Column: "year", Value: func(m models.Movie) any { return m.Year }},
+	"rating":     {Column: "rating", Value: func(m models.Movie) any { return m.Rating }},
+	"created_at": {Column: "created_at", Value: func(m models.Movie) any { return m.CreatedAt }},
+}
+
+// AwardFields are the award fields lists can filter and sort on
+var AwardFields = map[string]Field[models.Award]{
+	"name":     {Column: "name", Value: func(a models.Award) any { return a.Name }},
+	"category": {Column: "category", Value: func(a models.Award) any { return a.Category }},
+	"year":     {Column: "year", Value: func(a models.Award) any { return a.Year }},
+	"movie_id": {
+		Column: "movie_id",
+		Value: func(a models.Award) any {
+			if a.MovieID == nil {
+				return uuid.Nil
+			}
+			return *a.MovieID
+		},
+		IsNull: func(a models.Award) bool { return a.MovieID == nil },
+	},
+	"actor_id": {
+		Column: "actor_id",
+		Value: func(a models.Award) any {
+			if a.ActorID == nil {
+				return uuid.Nil
+			}
+			return *a.ActorID
+		},
+		IsNull: func(a models.Award) bool { return a.ActorID == nil },
+	},
+	"created_at": {Column: "created_at", Value: func(a models.Award) any { return a.CreatedAt }},
+}
@@ -0,0 +1,639 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gmdb/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// memoryStore holds every table in maps guarded by one lock, so repositories
+// that read across tables (cast, search) see a consistent snapshot
+type memoryStore struct {
+	mu      sync.RWMutex
+	actors  map[uuid.UUID]models.Actor
+	movies  map[uuid.UUID]models.Movie
+	awards  map[uuid.UUID]models.Award
+	credits map[creditKey]models.MovieActor
+	users   map[uuid.UUID]models.User
+	tokens  map[uuid.UUID]models.RefreshToken
+	apiKeys map[uuid.UUID]models.APIKey
+}
+
+type creditKey struct {
+	movieID uuid.UUID
+	actorID uuid.UUID
+}
+
+// NewMemoryRepositories creates thread-safe in-memory repositories for tests
+// and local development; they mirror the GORM behaviour including soft deletes
+func NewMemoryRepositories() *Repositories {
+	store := &memoryStore{
+		actors:  make(map[uuid.UUID]models.Actor),
+		movies:  make(map[uuid.UUID]models.Movie),
+		awards:  make(map[uuid.UUID]models.Award),
+		credits: make(map[creditKey]models.MovieActor),
+		users:   make(map[uuid.UUID]models.User),
+		tokens:  make(map[uuid.UUID]models.RefreshToken),
+		apiKeys: make(map[uuid.UUID]models.APIKey),
+	}
+	return &Repositories{
+		Actors:        &memoryActorRepository{store: store},
+		Movies:        &memoryMovieRepository{store: store},
+		Awards:        &memoryAwardRepository{store: store},
+		Search:        &memorySearchRepository{store: store},
+		Users:         &memoryUserRepository{store: store},
+		RefreshTokens: &memoryRefreshTokenRepository{store: store},
+		APIKeys:       &memoryAPIKeyRepository{store: store},
+	}
+}
+
+type memoryActorRepository struct {
+	store *memoryStore
+}
+
+func (r *memoryActorRepository) Create(_ context.Context, actor *models.Actor) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	return memCreate(r.store.actors, actor.ID, actor, func(a *models.Actor, now time.Time) {
+		a.CreatedAt, a.UpdatedAt = now, now
+	})
+}
+
+func (r *memoryActorRepository) Get(_ context.Context, id uuid.UUID) (*models.Actor, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	return memGet(r.store.actors, id, actorDeleted)
+}
+
+func (r *memoryActorRepository) GetMany(_ context.Context, ids []uuid.UUID) ([]models.Actor, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	return memGetMany(r.store.actors, ids, actorDeleted), nil
+}
+
+func (r *memoryActorRepository) List(_ context.Context, opts ListOptions) (*ListResult[models.Actor], error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	return memList(r.store.actors, ActorFields, opts, actorDeleted, func(a models.Actor) uuid.UUID { return a.ID })
+}
+
+func (r *memoryActorRepository) Count(_ context.Context) (int64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	return memCount(r.store.actors, actorDeleted), nil
+}
+
+func (r *memoryActorRepository) Update(_ context.Context, actor *models.Actor) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	actor.UpdatedAt = time.Now()
+	r.store.actors[actor.ID] = *actor
+	return nil
+}
+
+func (r *memoryActorRepository) Delete(_ context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	return memDelete(r.store.actors, id, actorDeleted, func(a *models.Actor, at gorm.DeletedAt) { a.DeletedAt = at })
+}
+
+func (r *memoryActorRepository) Restore(_ context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	return memRestore(r.store.actors, id, actorDeleted, func(a *models.Actor, at gorm.DeletedAt) { a.DeletedAt = at })
+}
+
+type memoryMovieRepository struct {
+	store *memoryStore
+}
+
+func (r *memoryMovieRepository) Create(_ context.Context, movie *models.Movie) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	return memCreate(r.store.movies, movie.ID, movie, func(m *models.Movie, now time.Time) {
+		m.CreatedAt, m.UpdatedAt = now, now
+	})
+}
+
+func (r *memoryMovieRepository) Get(_ context.Context, id uuid.UUID) (*models.Movie, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	return memGet(r.store.movies, id, movieDeleted)
+}
+
+func (r *memoryMovieRepository) GetMany(_ context.Context, ids []uuid.UUID) ([]models.Movie, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	return memGetMany(r.store.movies, ids, movieDeleted), nil
+}
+
+func (r *memoryMovieRepository) List(_ context.Context, opts ListOptions) (*ListResult[models.Movie], error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	return memList(r.store.movies, MovieFields, opts, movieDeleted, func(m models.Movie) uuid.UUID { return m.ID })
+}
+
+func (r *memoryMovieRepository) Count(_ context.Context) (int64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	return memCount(r.store.movies, movieDeleted), nil
+}
+
+func (r *memoryMovieRepository) Update(_ context.Context, movie *models.Movie) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	movie.UpdatedAt = time.Now()
+	r.store.movies[movie.ID] = *movie
+	return nil
+}
+
+func (r *memoryMovieRepository) Delete(_ context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	return memDelete(r.store.movies, id, movieDeleted, func(m *models.Movie, at gorm.DeletedAt) { m.DeletedAt = at })
+}
+
+func (r *memoryMovieRepository) Restore(_ context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	return memRestore(r.store.movies, id, movieDeleted, func(m *models.Movie, at gorm.DeletedAt) { m.DeletedAt = at })
+}
+
+func (r *memoryMovieRepository) UpsertCredit(_ context.Context, credit *models.MovieActor) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	r.store.credits[creditKey{credit.MovieID, credit.ActorID}] = *credit
+	return nil
+}
+
+func (r *memoryMovieRepository) DeleteCredit(_ context.Context, movieID, actorID uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	delete(r.store.credits, creditKey{movieID, actorID})
+	return nil
+}
+
+func (r *memoryMovieRepository) ListCredits(_ context.Context, movieID uuid.UUID) ([]models.MovieActor, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var credits []models.MovieActor
+	for key, credit := range r.store.credits {
+		if key.movieID == movieID {
+			credits = append(credits, credit)
+		}
+	}
+	// Billed credits first by billing order, unbilled (0) last; actor ID keeps ties stable
+	sort.Slice(credits, func(i, j int) bool {
+		a, b := credits[i], credits[j]
+		if (a.BillingOrder == 0) != (b.BillingOrder == 0) {
+			return b.BillingOrder == 0
+		}
+		if a.BillingOrder != b.BillingOrder {
+			return a.BillingOrder < b.BillingOrder
+		}
+		return bytes.Compare(a.ActorID[:], b.ActorID[:]) < 0
+	})
+	return credits, nil
+}
+
+func (r *memoryMovieRepository) ListCreditsByActor(_ context.Context, actorID uuid.UUID) ([]models.MovieActor, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var credits []models.MovieActor
+	for key, credit := range r.store.credits {
+		if key.actorID == actorID {
+			credits = append(credits, credit)
+		}
+	}
+	return credits, nil
+}
+
+type memoryAwardRepository struct {
+	store *memoryStore
+}
+
+func (r *memoryAwardRepository) Create(_ context.Context, award *models.Award) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	return memCreate(r.store.awards, award.ID, award, func(a *models.Award, now time.Time) {
+		a.CreatedAt, a.UpdatedAt = now, now
+	})
+}
+
+func (r *memoryAwardRepository) CreateMany(_ context.Context, awards []models.Award) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	// Check every ID first so a conflict leaves the store untouched
+	seen := make(map[uuid.UUID]bool, len(awards))
+	for _, award := range awards {
+		if _, exists := r.store.awards[award.ID]; exists || seen[award.ID] {
+			return fmt.Errorf("%w: award %s", ErrConflict, award.ID)
+		}
+		seen[award.ID] = true
+	}
+	now := time.Now()
+	for i := range awards {
+		awards[i].CreatedAt, awards[i].UpdatedAt = now, now
+		r.store.awards[awards[i].ID] = awards[i]
+	}
+	return nil
+}
+
+func (r *memoryAwardRepository) Get(_ context.Context, id uuid.UUID) (*models.Award, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	return memGet(r.store.awards, id, awardDeleted)
+}
+
+func (r *memoryAwardRepository) List(_ context.Context, opts ListOptions) (*ListResult[models.Award], error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	return memList(r.store.awards, AwardFields, opts, awardDeleted, func(a models.Award) uuid.UUID { return a.ID })
+}
+
+func (r *memoryAwardRepository) Count(_ context.Context) (int64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	return memCount(r.store.awards, awardDeleted), nil
+}
+
+func (r *memoryAwardRepository) Update(_ context.Context, award *models.Award) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	award.UpdatedAt = time.Now()
+	r.store.awards[award.ID] = *award
+	return nil
+}
+
+func (r *memoryAwardRepository) Delete(_ context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	return memDelete(r.store.awards, id, awardDeleted, func(a *models.Award, at gorm.DeletedAt) { a.DeletedAt = at })
+}
+
+func (r *memoryAwardRepository) Restore(_ context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	return memRestore(r.store.awards, id, awardDeleted, func(a *models.Award, at gorm.DeletedAt) { a.DeletedAt = at })
+}
+
+type memoryUserRepository struct {
+	store *memoryStore
+}
+
+func (r *memoryUserRepository) Create(_ context.Context, user *models.User) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	for _, existing := range r.store.users {
+		if existing.Email == user.Email && !userDeleted(existing) {
+			return fmt.Errorf("%w: email %s", ErrConflict, user.Email)
+		}
+	}
+	return memCreate(r.store.users, user.ID, user, func(u *models.User, now time.Time) {
+		u.CreatedAt, u.UpdatedAt = now, now
+	})
+}
+
+func (r *memoryUserRepository) Get(_ context.Context, id uuid.UUID) (*models.User, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	return memGet(r.store.users, id, userDeleted)
+}
+
+func (r *memoryUserRepository) GetByEmail(_ context.Context, email string) (*models.User, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	for _, user := range r.store.users {
+		if user.Email == email && !userDeleted(user) {
+			return &user, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+type memoryRefreshTokenRepository struct {
+	store *memoryStore
+}
+
+func (r *memoryRefreshTokenRepository) Create(_ context.Context, token *models.RefreshToken) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	return memCreate(r.store.tokens, token.ID, token, func(t *models.RefreshToken, now time.Time) {
+		t.CreatedAt = now
+	})
+}
+
+func (r *memoryRefreshTokenRepository) Consume(_ context.Context, hash string, now time.Time) (*models.RefreshToken, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	for id, token := range r.store.tokens {
+		if token.TokenHash != hash || token.RevokedAt != nil || !token.ExpiresAt.After(now) {
+			continue
+		}
+		token.RevokedAt = &now
+		r.store.tokens[id] = token
+		return &token, nil
+	}
+	return nil, ErrNotFound
+}
+
+type memoryAPIKeyRepository struct {
+	store *memoryStore
+}
+
+func (r *memoryAPIKeyRepository) Create(_ context.Context, key *models.APIKey) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	for _, existing := range r.store.apiKeys {
+		if existing.Prefix == key.Prefix || existing.SecretHash == key.SecretHash {
+			return fmt.Errorf("%w: API key prefix %s", ErrConflict, key.Prefix)
+		}
+	}
+	return memCreate(r.store.apiKeys, key.ID, key, func(k *models.APIKey, now time.Time) {
+		k.CreatedAt = now
+	})
+}
+
+func (r *memoryAPIKeyRepository) List(_ context.Context) ([]models.APIKey, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	keys := make([]models.APIKey, 0, len(r.store.apiKeys))
+	for _, key := range r.store.apiKeys {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if !keys[i].CreatedAt.Equal(keys[j].CreatedAt) {
+			return keys[i].CreatedAt.After(keys[j].CreatedAt)
+		}
+		return bytes.Compare(keys[i].ID[:], keys[j].ID[:]) > 0
+	})
+	return keys, nil
+}
+
+func (r *memoryAPIKeyRepository) Get(_ context.Context, id uuid.UUID) (*models.APIKey, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	return memGet(r.store.apiKeys, id, func(models.APIKey) bool { return false })
+}
+
+func (r *memoryAPIKeyRepository) GetByPrefix(_ context.Context, prefix string) (*models.APIKey, error) {
+	return r.find(func(k models.APIKey) bool { return k.Prefix == prefix })
+}
+
+func (r *memoryAPIKeyRepository) GetBySecretHash(_ context.Context, hash string) (*models.APIKey, error) {
+	return r.find(func(k models.APIKey) bool { return k.SecretHash == hash })
+}
+
+func (r *memoryAPIKeyRepository) find(match func(models.APIKey) bool) (*models.APIKey, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	for _, key := range r.store.apiKeys {
+		if match(key) {
+			return &key, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (r *memoryAPIKeyRepository) Revoke(_ context.Context, id uuid.UUID, at time.Time) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	key, ok := r.store.apiKeys[id]
+	if !ok || key.RevokedAt != nil {
+		return ErrNotFound
+	}
+	key.RevokedAt = &at
+	r.store.apiKeys[id] = key
+	return nil
+}
+
+func (r *memoryAPIKeyRepository) MarkUsed(_ context.Context, id uuid.UUID, at time.Time) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if key, ok := r.store.apiKeys[id]; ok {
+		key.LastUsedAt = &at
+		r.store.apiKeys[id] = key
+	}
+	return nil
+}
+
+func actorDeleted(a models.Actor) bool { return a.DeletedAt.Valid }
+func movieDeleted(m models.Movie) bool { return m.DeletedAt.Valid }
+func awardDeleted(a models.Award) bool { return a.DeletedAt.Valid }
+func userDeleted(u models.User) bool   { return u.DeletedAt.Valid }
+
+// memCreate stores row under id, stamping timestamps; callers hold the write lock
+func memCreate[M any](table map[uuid.UUID]M, id uuid.UUID, row *M, stamp func(*M, time.Time)) error {
+	if _, exists := table[id]; exists {
+		return fmt.Errorf("%w: %s", ErrConflict, id)
+	}
+	stamp(row, time.Now())
+	table[id] = *row
+	return nil
+}
+
+func memGet[M any](table map[uuid.UUID]M, id uuid.UUID, deleted func(M) bool) (*M, error) {
+	row, ok := table[id]
+	if !ok || deleted(row) {
+		return nil, ErrNotFound
+	}
+	return &row, nil
+}
+
+func memGetMany[M any](table map[uuid.UUID]M, ids []uuid.UUID, deleted func(M) bool) []M {
+	var rows []M
+	for _, id := range ids {
+		if row, ok := table[id]; ok && !deleted(row) {
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+func memCount[M any](table map[uuid.UUID]M, deleted func(M) bool) int64 {
+	var count int64
+	for _, row := range table {
+		if !deleted(row) {
+			count++
+		}
+	}
+	return count
+}
+
+func memDelete[M any](table map[uuid.UUID]M, id uuid.UUID, deleted func(M) bool, mark func(*M, gorm.DeletedAt)) error {
+	row, ok := table[id]
+	if !ok || deleted(row) {
+		return ErrNotFound
+	}
+	mark(&row, gorm.DeletedAt{Time: time.Now(), Valid: true})
+	table[id] = row
+	return nil
+}
+
+func memRestore[M any](table map[uuid.UUID]M, id uuid.UUID, deleted func(M) bool, mark func(*M, gorm.DeletedAt)) error {
+	row, ok := table[id]
+	if !ok || !deleted(row) {
+		return ErrNotFound
+	}
+	mark(&row, gorm.DeletedAt{})
+	table[id] = row
+	return nil
+}
+
+// memList filters, sorts and pages rows with the same semantics as gormList
+func memList[M any](table map[uuid.UUID]M, fields map[string]Field[M], opts ListOptions, deleted func(M) bool, idOf func(M) uuid.UUID) (*ListResult[M], error) {
+	for _, filter := range opts.Filters {
+		if _, ok := fields[filter.Field]; !ok {
+			return nil, fmt.Errorf("unknown filter field %q", filter.Field)
+		}
+	}
+	for _, key := range opts.Sort {
+		if _, ok := fields[key.Field]; !ok {
+			return nil, fmt.Errorf("unknown sort field %q", key.Field)
+		}
+	}
+
+	var rows []M
+	for _, row := range table {
+		if deleted(row) || !memMatches(row, fields, opts.Filters) {
+			continue
+		}
+		rows = append(rows, row)
+	}
+
+	result := &ListResult[M]{}
+	if opts.IncludeTotal {
+		total := int64(len(rows))
+		result.Total = &total
+	}
+
+	// compareRow orders a row against sort values plus an ID, like the SQL ORDER BY
+	compareRow := func(row M, values []any, id uuid.UUID) int {
+		for i, key := range opts.Sort {
+			c := compareValues(fields[key.Field].Value(row), values[i])
+			if key.Desc {
+				c = -c
+			}
+			if c != 0 {
+				return c
+			}
+		}
+		rowID := idOf(row)
+		return bytes.Compare(rowID[:], id[:])
+	}
+	sortValues := func(row M) []any {
+		values := make([]any, len(opts.Sort))
+		for i, key := range opts.Sort {
+			values[i] = fields[key.Field].Value(row)
+		}
+		return values
+	}
+
+	sort.Slice(rows, func(i, j int) bool {
+		return compareRow(rows[i], sortValues(rows[j]), idOf(rows[j])) < 0
+	})
+
+	if opts.After != nil {
+		start := len(rows)
+		for i, row := range rows {
+			if compareRow(row, opts.After.Values, opts.After.ID) > 0 {
+				start = i
+				break
+			}
+		}
+		rows = rows[start:]
+	}
+
+	if len(rows) > opts.Limit {
+		rows = rows[:opts.Limit]
+		result.HasMore = true
+	}
+	result.Items = rows
+	return result, nil
+}
+
+func memMatches[M any](row M, fields map[string]Field[M], filters []Filter) bool {
+	for _, filter := range filters {
+		field := fields[filter.Field]
+		if field.IsNull != nil && field.IsNull(row) {
+			return false
+		}
+		value := field.Value(row)
+
+		var ok bool
+		switch filter.Op {
+		case OpEq:
+			ok = compareValues(value, filter.Value) == 0
+		case OpEqFold:
+			ok = strings.EqualFold(fmt.Sprint(value), fmt.Sprint(filter.Value))
+		case OpContains:
+			ok = strings.Contains(strings.ToLower(fmt.Sprint(value)), strings.ToLower(fmt.Sprint(filter.Value)))
+		case OpLt:
+			ok = compareValues(value, filter.Value) < 0
+		case OpGte:
+			ok = compareValues(value, filter.Value) >= 0
+		case OpLte:
+			ok = compareValues(value, filter.Value) <= 0
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// compareValues orders two field values of the same kind
+func compareValues(a, b any) int {
+	switch x := a.(type) {
+	case string:
+		return strings.Compare(x, b.(string))
+	case int:
+		return compareOrdered(float64(x), toFloat(b))
+	case float64:
+		return compareOrdered(x, toFloat(b))
+	case time.Time:
+		return x.Compare(b.(time.Time))
+	case uuid.UUID:
+		y := b.(uuid.UUID)
+		return bytes.Compare(x[:], y[:])
+	default:
+		return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+	}
+}
+
+func toFloat(v any) float64 {
+	switch n := v.(type) {
+	case int:
+		return float64(n)
+	case int64:
+		return float64(n)
+	case float64:
+		return n
+	default:
+		return 0
+	}
+}
+
+func compareOrdered(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Field weights follow ts_rank's defaults for labels A, B and C
+const (
+	weightA = 1.0
+	weightB = 0.4
+	weightC = 0.2
+)
+
+type memorySearchRepository struct {
+	store *memoryStore
+}
+
+// searchDoc is one searchable row with its weighted text fields
+type searchDoc struct {
+	kind   string
+	id     uuid.UUID
+	title  string
+	fields []weightedText
+}
+
+type weightedText struct {
+	text   string
+	weight float64
+}
+
+// Search approximates websearch_to_tsquery: every plain term must appear
+// (case-insensitive substring), and "-term" excludes rows containing term
+func (r *memorySearchRepository) Search(_ context.Context, query string, types []string, limit int) ([]SearchHit, error) {
+	include, exclude := parseSearchTerms(query)
+	if len(include) == 0 {
+		return []SearchHit{}, nil
+	}
+
+	r.store.mu.RLock()
+	docs := r.documents(types)
+	r.store.mu.RUnlock()
+
+	hits := []SearchHit{}
+	for _, doc := range docs {
+		rank, ok := rankDocument(doc, include, exclude)
+		if !ok {
+			continue
+		}
+		hits = append(hits, SearchHit{
+			Type:    doc.kind,
+			ID:      doc.id,
+			Title:   doc.title,
+			Snippet: highlight(doc, include),
+			Rank:    rank,
+		})
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Rank != hits[j].Rank {
+			return hits[i].Rank > hits[j].Rank
+		}
+		return hits[i].Title < hits[j].Title
+	})
+	if len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits, nil
+}
+
+// documents collects live rows of the requested types; callers hold the read lock
+func (r *memorySearchRepository) documents(types []string) []searchDoc {
+	var docs []searchDoc
+	for _, t := range types {
+		switch t {
+		case "movie":
+			for _, m := range r.store.movies {
+				if !movieDeleted(m) {
+					docs = append(docs, searchDoc{"movie", m.ID, m.Title, []weightedText{
+						{m.Title, weightA}, {m.Director, weightB}, {m.Description, weightC},
+					}})
+				}
+			}
+		case "actor":
+			for _, a := range r.store.actors {
+				if !actorDeleted(a) {
+					docs = append(docs, searchDoc{"actor", a.ID, a.Name, []weightedText{
+						{a.Name, weightA}, {a.Biography, weightC},
+					}})
+				}
+			}
+		case "award":
+			for _, a := range r.store.awards {
+				if !awardDeleted(a) {
+					docs = append(docs, searchDoc{"award", a.ID, a.Name, []weightedText{
+						{a.Name, weightA}, {a.Description, weightC},
+					}})
+				}
+			}
+		}
+	}
+	return docs
+}
+
+func parseSearchTerms(query string) (include, exclude []string) {
+	for _, term := range strings.Fields(strings.ToLower(strings.ReplaceAll(query, `"`, " "))) {
+		switch {
+		case term == "or":
+			continue
+		case strings.HasPrefix(term, "-") && len(term) > 1:
+			exclude = append(exclude, term[1:])
+		default:
+			include = append(include, term)
+		}
+	}
+	return include, exclude
+}
+
+func rankDocument(doc searchDoc, include, exclude []string) (float64, bool) {
+	for _, term := range exclude {
+		for _, field := range doc.fields {
+			if strings.Contains(strings.ToLower(field.text), term) {
+				return 0, false
+			}
+		}
+	}
+
+	rank := 0.0
+	for _, term := range include {
+		matched := false
+		for _, field := range doc.fields {
+			if strings.Contains(strings.ToLower(field.text), term) {
+				rank += field.weight
+				matched = true
+			}
+		}
+		if !matched {
+			return 0, false
+		}
+	}
+	return rank, true
+}
+
+// highlight wraps each matched term in <mark>, like ts_headline, escaping
+// the rest of the text
+func highlight(doc searchDoc, include []string) string {
+	parts := make([]string, 0, len(doc.fields))
+	for _, field := range doc.fields {
+		if field.text != "" {
+			parts = append(parts, field.text)
+		}
+	}
+	text := strings.Join(parts, " ")
+
+	quoted := make([]string, len(include))
+	for i, term := range include {
+		quoted[i] = regexp.QuoteMeta(term)
+	}
+	pattern := regexp.MustCompile(`(?i)(` + strings.Join(quoted, "|") + `)`)
+	return markSnippet(pattern.ReplaceAllString(text, matchStart+"$1"+matchStop))
+}
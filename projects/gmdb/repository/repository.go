@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gmdb/models"
+
+	"github.com/google/uuid"
+)
+
+// Errors returned by every repository implementation
+var (
+	ErrNotFound = errors.New("record not found")
+	ErrConflict = errors.New("record conflicts with an existing one")
+)
+
+// ActorRepository stores actors; soft-deleted actors are invisible to every read
+type ActorRepository interface {
+	Create(ctx context.Context, actor *models.Actor) error
+	Get(ctx context.Context, id uuid.UUID) (*models.Actor, error)
+	GetMany(ctx context.Context, ids []uuid.UUID) ([]models.Actor, error)
+	List(ctx context.Context, opts ListOptions) (*ListResult[models.Actor], error)
+	// Count returns the number of live (not soft-deleted) rows
+	Count(ctx context.Context) (int64, error)
+	Update(ctx context.Context, actor *models.Actor) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	// Restore undeletes a soft-deleted row; missing and live rows return ErrNotFound
+	Restore(ctx context.Context, id uuid.UUID) error
+}
+
+// MovieRepository stores movies and their cast credits (the movie_actors table)
+type MovieRepository interface {
+	Create(ctx context.Context, movie *models.Movie) error
+	Get(ctx context.Context, id uuid.UUID) (*models.Movie, error)
+	GetMany(ctx context.Context, ids []uuid.UUID) ([]models.Movie, error)
+	List(ctx context.Context, opts ListOptions) (*ListResult[models.Movie], error)
+	// Count returns the number of live (not soft-deleted) rows
+	Count(ctx context.Context) (int64, error)
+	Update(ctx context.Context, movie *models.Movie) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	// Restore undeletes a soft-deleted row; missing and live rows return ErrNotFound
+	Restore(ctx context.Context, id uuid.UUID) error
+
+	// UpsertCredit creates the credit or replaces its role fields
+	UpsertCredit(ctx context.Context, credit *models.MovieActor) error
+	// DeleteCredit removes a credit; a missing credit is not an error
+	DeleteCredit(ctx context.Context, movieID, actorID uuid.UUID) error
+	// ListCredits returns a movie's credits, billed first by billing order, unbilled (0) last
+	ListCredits(ctx context.Context, movieID uuid.UUID) ([]models.MovieActor, error)
+	// ListCreditsByActor returns every credit of an actor
+	ListCreditsByActor(ctx context.Context, actorID uuid.UUID) ([]models.MovieActor, error)
+}
+
+// AwardRepository stores awards
+type AwardRepository interface {
+	Create(ctx context.Context, award *models.Award) error
+	// CreateMany stores all awards or none of them
+	CreateMany(ctx context.Context, awards []models.Award) error
+	Get(ctx context.Context, id uuid.UUID) (*models.Award, error)
+	List(ctx context.Context, opts ListOptions) (*ListResult[models.Award], error)
+	// Count returns the number of live (not soft-deleted) rows
+	Count(ctx context.Context) (int64, error)
+	Update(ctx context.Context, award *models.Award) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	// Restore undeletes a soft-deleted row; missing and live rows return ErrNotFound
+	Restore(ctx context.Context, id uuid.UUID) error
+}
+
+// UserRepository stores API users; emails are unique among live users
+type UserRepository interface {
+	Create(ctx context.Context, user *models.User) error
+	Get(ctx context.Context, id uuid.UUID) (*models.User, error)
+	// GetByEmail matches the stored (lower-case) email exactly
+	GetByEmail(ctx context.Context, email string) (*models.User, error)
+}
+
+// RefreshTokenRepository stores refresh tokens by the hash of their secret
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, token *models.RefreshToken) error
+	// Consume revokes the live token with the given hash and returns it.
+	// Only one caller can consume a token; expired, revoked and unknown
+	// tokens return ErrNotFound.
+	Consume(ctx context.Context, hash string, now time.Time) (*models.RefreshToken, error)
+}
+
+// APIKeyRepository stores API keys, revoked ones included
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *models.APIKey) error
+	// List returns every key, newest first
+	List(ctx context.Context) ([]models.APIKey, error)
+	Get(ctx context.Context, id uuid.UUID) (*models.APIKey, error)
+	GetByPrefix(ctx context.Context, prefix string) (*models.APIKey, error)
+	GetBySecretHash(ctx context.Context, hash string) (*models.APIKey, error)
+	// Revoke marks a live key revoked; missing or already revoked keys return ErrNotFound
+	Revoke(ctx context.Context, id uuid.UUID, at time.Time) error
+	// MarkUsed records when a key last authenticated a request
+	MarkUsed(ctx context.Context, id uuid.UUID, at time.Time) error
+}
+
+// SearchRepository runs ranked full-text search across resource types
+type SearchRepository interface {
+	Search(ctx context.Context, query string, types []string, limit int) ([]SearchHit, error)
+}
+
+// SearchHit is one ranked search match
+type SearchHit struct {
+	Type    string
+	ID      uuid.UUID
+	Title   string
+	Snippet string // HTML-escaped text with matches wrapped in <mark></mark>
+	Rank    float64
+}
+
+// Repositories bundles one implementation of every repository
+type Repositories struct {
+	Actors        ActorRepository
+	Movies        MovieRepository
+	Awards        AwardRepository
+	Search        SearchRepository
+	Users         UserRepository
+	RefreshTokens RefreshTokenRepository
+	APIKeys       APIKeyRepository
+}
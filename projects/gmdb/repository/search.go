@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"html"
+	"strings"
+)
+
+// Matches are first delimited with control characters that stored text never
+// renders, so snippets can be HTML-escaped before <mark> tags are added
+const (
+	matchStart = "\x02"
+	matchStop  = "\x03"
+)
+
+// snippetReplacer turns the match delimiters into <mark> tags
+var snippetReplacer = strings.NewReplacer(matchStart, "<mark>", matchStop, "</mark>")
+
+// markSnippet escapes a delimited snippet so stored markup is shown as text,
+// then wraps the matches in <mark>
+func markSnippet(snippet string) string {
+	return snippetReplacer.Replace(html.EscapeString(snippet))
+}
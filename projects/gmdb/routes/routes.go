@@ -1,29 +1,101 @@
 package routes
 
 import (
+	"time"
+
+	"gmdb/config"
 	handlers "gmdb/handlers"
+	"gmdb/middleware"
+	"gmdb/models"
 
 	"github.com/gin-gonic/gin"
 )
 
-func SetupRoutes(r *gin.Engine) {
+// APIv1Prefix is the base path of the current API version
+const APIv1Prefix = "/api/v1"
+
+// Root routes were deprecated when /api/v1 shipped and are removed one release later
+var (
+	legacyDeprecatedAt = time.Date(2026, time.October, 16, 0, 0, 0, 0, time.UTC)
+	legacySunsetAt     = time.Date(2027, time.April, 16, 0, 0, 0, 0, time.UTC)
+)
+
+// Resource routes require a scope, which users hold through their role (see
+// models.Scope.Role) and API keys hold explicitly. User and API key
+// management is for admin users only.
+var (
+	can   = middleware.RequireScope
+	admin = middleware.RequireRole(models.RoleAdmin)
+)
+
+// SetupRoutes mounts every route; live supplies the runtime feature flags,
+// authenticate identifies the callers of API routes and rateLimit throttles
+// them per route group (see config.RateLimitGroups)
+func SetupRoutes(r *gin.Engine, h *handlers.Handlers, live *config.Store, authenticate gin.HandlerFunc, rateLimit func(group string) gin.HandlerFunc) {
 	r.GET("/ping", handlers.HandlePing)
 
-	r.GET("/actors/", handlers.HandleGetActors)
-	r.GET("/actors/:id", handlers.HandleGetActor)
-	r.POST("/actors/", handlers.HandleCreateActor)
-	r.PUT("/actors/:id", handlers.HandleUpdateActor)
-	r.DELETE("/actors/:id", handlers.HandleDeleteActor)
-
-	r.GET("/movies/", handlers.HandleGetMovies)
-	r.GET("/movies/:id", handlers.HandleGetMovie)
-	r.POST("/movies/", handlers.HandleCreateMovie)
-	r.PUT("/movies/:id", handlers.HandleUpdateMovie)
-	r.DELETE("/movies/:id", handlers.HandleDeleteMovie)
-
-	r.GET("/awards/", handlers.HandleGetAwards)
-	r.GET("/awards/:id", handlers.HandleGetAward)
-	r.POST("/awards/", handlers.HandleCreateAward)
-	r.PUT("/awards/:id", handlers.HandleUpdateAward)
-	r.DELETE("/awards/:id", handlers.HandleDeleteAward)
+	// Orchestrator probes, build info and Prometheus metrics, outside API versioning
+	r.GET("/healthz", h.Health.Healthz)
+	r.GET("/readyz", h.Health.Readyz)
+	r.GET("/version", h.Health.Version)
+	r.GET("/metrics", gin.WrapH(h.Metrics))
+
+	// Versioned API; a future v2 is added as another group under /api
+	api := r.Group("/api", authenticate)
+	v1 := api.Group("/v1")
+	resources := v1.Group("", rateLimit("api"))
+	registerV1Routes(resources, h)
+
+	// Endpoints added after versioning are only served under /api/v1
+	v1.GET("/search", rateLimit("search"), can("search:read"), middleware.RequireFeature("search", func(name string) bool {
+		return live.Current().Enabled(name)
+	}), h.Search.Search)
+
+	// Restoring undoes a delete, so it needs the same scope. Like search it
+	// came after versioning, so it is deliberately v1-only and not legacy
+	resources.POST("/movies/:id/restore", can("movies:delete"), h.Movies.RestoreMovie)
+	resources.POST("/actors/:id/restore", can("actors:delete"), h.Actors.RestoreActor)
+	resources.POST("/awards/:id/restore", can("awards:delete"), h.Awards.RestoreAward)
+
+	// Login and token refresh are how callers get credentials, so they are public
+	authn := v1.Group("/auth", rateLimit("auth"))
+	authn.POST("/login", h.Auth.Login)
+	authn.POST("/refresh", h.Auth.Refresh)
+	authn.POST("/logout", h.Auth.Logout)
+	resources.POST("/users/", admin, h.Auth.CreateUser)
+	resources.GET("/api-keys/", admin, h.APIKeys.GetAPIKeys)
+	resources.POST("/api-keys/", admin, h.APIKeys.CreateAPIKey)
+	resources.DELETE("/api-keys/:id", admin, h.APIKeys.RevokeAPIKey)
+
+	// Legacy root routes, kept for one release with deprecation headers; they
+	// share the allowance of their /api/v1 equivalents
+	legacy := r.Group("/", authenticate, rateLimit("api"), middleware.Deprecated(legacyDeprecatedAt, legacySunsetAt, APIv1Prefix))
+	registerV1Routes(legacy, h)
+}
+
+// registerV1Routes mounts the v1 resources on the given group
+func registerV1Routes(g *gin.RouterGroup, h *handlers.Handlers) {
+	g.GET("/actors/", can("actors:read"), h.Actors.GetActors)
+	g.GET("/actors/:id", can("actors:read"), h.Actors.GetActor)
+	g.POST("/actors/", can("actors:write"), h.Actors.CreateActor)
+	g.PUT("/actors/:id", can("actors:write"), h.Actors.UpdateActor)
+	g.DELETE("/actors/:id", can("actors:delete"), h.Actors.DeleteActor)
+	g.GET("/actors/:id/movies", can("actors:read"), h.Cast.GetActorMovies)
+
+	g.GET("/movies/", can("movies:read"), h.Movies.GetMovies)
+	g.GET("/movies/:id", can("movies:read"), h.Movies.GetMovie)
+	g.POST("/movies/", can("movies:write"), h.Movies.CreateMovie)
+	g.PUT("/movies/:id", can("movies:write"), h.Movies.UpdateMovie)
+	g.DELETE("/movies/:id", can("movies:delete"), h.Movies.DeleteMovie)
+	g.GET("/movies/:id/actors", can("movies:read"), h.Cast.GetMovieCast)
+	g.POST("/movies/:id/actors", can("movies:write"), h.Cast.AddMovieActor)
+	g.DELETE("/movies/:id/actors/:actor_id", can("movies:write"), h.Cast.RemoveMovieActor)
+
+	g.GET("/awards/", can("awards:read"), h.Awards.GetAwards)
+	g.GET("/awards/grouped", can("awards:read"), h.Awards.GetAwardsGrouped)
+	g.POST("/awards/grouped", can("awards:write"), h.Awards.CreateAwardGroup)
+	g.GET("/awards/:id", can("awards:read"), h.Awards.GetAward)
+	g.POST("/awards/", can("awards:write"), h.Awards.CreateAward)
+	g.PUT("/awards/:id", can("awards:write"), h.Awards.UpdateAward)
+	g.DELETE("/awards/:id", can("awards:delete"), h.Awards.DeleteAward)
 }
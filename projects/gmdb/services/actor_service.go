@@ -1,28 +1,43 @@
 package services
 
 import (
+	"context"
 	"errors"
 	"time"
 
 	"gmdb/models"
+	"gmdb/repository"
 	"gmdb/utils"
 
 	"github.com/google/uuid"
-	"gorm.io/gorm"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type ActorService struct {
-	db *gorm.DB
+	tracer trace.Tracer
+	actors repository.ActorRepository
 }
 
 // NewActorService creates a new actor service instance
-func NewActorService(db *gorm.DB) *ActorService {
-	return &ActorService{db: db}
+func NewActorService(actors repository.ActorRepository, tracer trace.Tracer) *ActorService {
+	return &ActorService{tracer: tracer, actors: actors}
+}
+
+// actorListSpec is the allow-list of actor filters and sort fields
+var actorListSpec = listSpec[models.Actor]{
+	filters: map[string]filterSpec{
+		"name_contains": {"name", repository.OpContains, parseString},
+		"born_before":   {"birth_date", repository.OpLt, parseDateStart},
+		"born_after":    {"birth_date", repository.OpGte, parseDateEnd},
+	},
+	sorts:  []string{"name", "birth_date", "created_at"},
+	fields: repository.ActorFields,
+	id:     func(a models.Actor) uuid.UUID { return a.ID },
 }
 
 // CreateActorRequest represents the input for creating an actor
 type CreateActorRequest struct {
-	Name      string     `json:"name" binding:"required"`
+	Name      string     `json:"name"`
 	BirthDate *time.Time `json:"birth_date"`
 	Biography string     `json:"biography"`
 }
@@ -38,7 +53,10 @@ type ActorResponse struct {
 }
 
 // CreateActor handles the business logic for creating a new actor
-func (s *ActorService) CreateActor(req CreateActorRequest) (*ActorResponse, error) {
+func (s *ActorService) CreateActor(ctx context.Context, req CreateActorRequest) (_ *ActorResponse, err error) {
+	ctx, end := startSpan(ctx, s.tracer, "ActorService.CreateActor")
+	defer end(&err)
+
 	// Business validation
 	if err := s.validateCreateActor(req); err != nil {
 		return nil, err
@@ -53,8 +71,8 @@ func (s *ActorService) CreateActor(req CreateActorRequest) (*ActorResponse, erro
 	}
 
 	// Save to database
-	if err := s.db.Create(&actor).Error; err != nil {
-		return nil, errors.New("failed to create actor")
+	if err := s.actors.Create(ctx, &actor); err != nil {
+		return nil, writeError(err, "failed to create actor")
 	}
 
 	// Transform to response
@@ -62,54 +80,35 @@ func (s *ActorService) CreateActor(req CreateActorRequest) (*ActorResponse, erro
 }
 
 // GetActor retrieves an actor by ID
-func (s *ActorService) GetActor(id uuid.UUID) (*ActorResponse, error) {
-	var actor models.Actor
+func (s *ActorService) GetActor(ctx context.Context, id uuid.UUID) (_ *ActorResponse, err error) {
+	ctx, end := startSpan(ctx, s.tracer, "ActorService.GetActor")
+	defer end(&err)
 
-	if err := s.db.First(&actor, "id = ?", id).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("actor not found")
-		}
-		return nil, errors.New("failed to retrieve actor")
+	actor, err := s.findActor(ctx, id)
+	if err != nil {
+		return nil, err
 	}
 
-	return s.toResponse(actor), nil
+	return s.toResponse(*actor), nil
 }
 
-// GetAllActors retrieves all actors with optional filters
-func (s *ActorService) GetAllActors(limit, offset int) ([]*ActorResponse, error) {
-	var actors []models.Actor
+// GetAllActors retrieves one page of actors matching the allow-listed filters and sort
+func (s *ActorService) GetAllActors(ctx context.Context, q ListQuery) (_ *Page[*ActorResponse], err error) {
+	ctx, end := startSpan(ctx, s.tracer, "ActorService.GetAllActors")
+	defer end(&err)
 
-	query := s.db.Model(&models.Actor{})
-	if limit > 0 {
-		query = query.Limit(limit)
-	}
-	if offset > 0 {
-		query = query.Offset(offset)
-	}
-
-	if err := query.Find(&actors).Error; err != nil {
-		return nil, errors.New("failed to retrieve actors")
-	}
-
-	// Transform to responses
-	responses := make([]*ActorResponse, len(actors))
-	for i, actor := range actors {
-		responses[i] = s.toResponse(actor)
-	}
-
-	return responses, nil
+	return listPage(ctx, actorListSpec, q, s.actors.List, s.toResponse, "failed to retrieve actors")
 }
 
 // UpdateActor updates an existing actor
-func (s *ActorService) UpdateActor(id uuid.UUID, req CreateActorRequest) (*ActorResponse, error) {
-	var actor models.Actor
+func (s *ActorService) UpdateActor(ctx context.Context, id uuid.UUID, req CreateActorRequest) (_ *ActorResponse, err error) {
+	ctx, end := startSpan(ctx, s.tracer, "ActorService.UpdateActor")
+	defer end(&err)
 
 	// Check if actor exists
-	if err := s.db.First(&actor, "id = ?", id).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("actor not found")
-		}
-		return nil, errors.New("failed to retrieve actor")
+	actor, err := s.findActor(ctx, id)
+	if err != nil {
+		return nil, err
 	}
 
 	// Business validation
@@ -122,37 +121,70 @@ func (s *ActorService) UpdateActor(id uuid.UUID, req CreateActorRequest) (*Actor
 	actor.BirthDate = req.BirthDate
 	actor.Biography = req.Biography
 
-	if err := s.db.Save(&actor).Error; err != nil {
-		return nil, errors.New("failed to update actor")
+	if err := s.actors.Update(ctx, actor); err != nil {
+		return nil, writeError(err, "failed to update actor")
 	}
 
-	return s.toResponse(actor), nil
+	return s.toResponse(*actor), nil
 }
 
 // DeleteActor soft deletes an actor
-func (s *ActorService) DeleteActor(id uuid.UUID) error {
-	result := s.db.Delete(&models.Actor{}, "id = ?", id)
-	if result.Error != nil {
-		return errors.New("failed to delete actor")
-	}
-	if result.RowsAffected == 0 {
-		return errors.New("actor not found")
+func (s *ActorService) DeleteActor(ctx context.Context, id uuid.UUID) (err error) {
+	ctx, end := startSpan(ctx, s.tracer, "ActorService.DeleteActor")
+	defer end(&err)
+
+	if err := s.actors.Delete(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return notFoundError("actor_not_found", "actor not found")
+		}
+		return internalError("failed to delete actor", err)
 	}
 	return nil
 }
 
+// RestoreActor undeletes a soft-deleted actor and returns it
+func (s *ActorService) RestoreActor(ctx context.Context, id uuid.UUID) (_ *ActorResponse, err error) {
+	ctx, end := startSpan(ctx, s.tracer, "ActorService.RestoreActor")
+	defer end(&err)
+
+	if err := s.actors.Restore(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, notFoundError("actor_not_found", "deleted actor not found")
+		}
+		return nil, internalError("failed to restore actor", err)
+	}
+
+	actor, err := s.findActor(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.toResponse(*actor), nil
+}
+
+// findActor loads an actor, mapping a missing row to actor_not_found
+func (s *ActorService) findActor(ctx context.Context, id uuid.UUID) (*models.Actor, error) {
+	actor, err := s.actors.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, notFoundError("actor_not_found", "actor not found")
+		}
+		return nil, internalError("failed to retrieve actor", err)
+	}
+	return actor, nil
+}
+
 // Business logic validation
 func (s *ActorService) validateCreateActor(req CreateActorRequest) error {
+	var errs fieldErrors
 	if req.Name == "" {
-		return errors.New("actor name is required")
-	}
-	if len(req.Name) < 2 {
-		return errors.New("actor name must be at least 2 characters")
+		errs.add("name", "required", "actor name is required")
+	} else if len(req.Name) < 2 {
+		errs.add("name", "too_short", "actor name must be at least 2 characters")
 	}
 	if req.BirthDate != nil && req.BirthDate.After(time.Now()) {
-		return errors.New("birth date cannot be in the future")
+		errs.add("birth_date", "in_future", "birth date cannot be in the future")
 	}
-	return nil
+	return errs.err()
 }
 
 // Transform model to response DTO
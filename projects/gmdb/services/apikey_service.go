@@ -0,0 +1,195 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"gmdb/auth"
+	"gmdb/models"
+	"gmdb/repository"
+	"gmdb/utils"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// CodeInvalidAPIKey is returned for unknown, expired and revoked API keys
+const CodeInvalidAPIKey = "invalid_api_key"
+
+// lastUsedResolution bounds how often authenticating with a key writes its
+// last-used time
+const lastUsedResolution = time.Minute
+
+type APIKeyService struct {
+	tracer trace.Tracer
+	keys   repository.APIKeyRepository
+}
+
+// NewAPIKeyService creates a new API key service instance
+func NewAPIKeyService(keys repository.APIKeyRepository, tracer trace.Tracer) *APIKeyService {
+	return &APIKeyService{tracer: tracer, keys: keys}
+}
+
+// CreateAPIKeyRequest represents the input for creating an API key
+type CreateAPIKeyRequest struct {
+	Name   string         `json:"name"`
+	Scopes []models.Scope `json:"scopes"`
+	// ExpiresAt is optional; keys without it never expire
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// APIKeyResponse represents the output format for an API key; the key itself
+// is never shown again after creation
+type APIKeyResponse struct {
+	ID         uuid.UUID      `json:"id"`
+	Name       string         `json:"name"`
+	Prefix     string         `json:"prefix"`
+	Scopes     []models.Scope `json:"scopes"`
+	ExpiresAt  *time.Time     `json:"expires_at"`
+	LastUsedAt *time.Time     `json:"last_used_at"`
+	RevokedAt  *time.Time     `json:"revoked_at"`
+	CreatedAt  time.Time      `json:"created_at"`
+}
+
+// CreatedAPIKeyResponse is a new API key including its secret
+type CreatedAPIKeyResponse struct {
+	*APIKeyResponse
+	Key string `json:"key"`
+}
+
+// CreateAPIKey generates a key with the given scopes and stores its hash
+func (s *APIKeyService) CreateAPIKey(ctx context.Context, req CreateAPIKeyRequest) (_ *CreatedAPIKeyResponse, err error) {
+	ctx, end := startSpan(ctx, s.tracer, "APIKeyService.CreateAPIKey")
+	defer end(&err)
+
+	if err := s.validateCreateAPIKey(req); err != nil {
+		return nil, err
+	}
+
+	secret, prefix, err := auth.GenerateAPIKey()
+	if err != nil {
+		return nil, internalError("failed to generate API key", err)
+	}
+	key := models.APIKey{
+		ID:         utils.NewUUIDv7(),
+		Name:       strings.TrimSpace(req.Name),
+		Prefix:     prefix,
+		SecretHash: auth.HashSecret(secret),
+		Scopes:     slices.Compact(slices.Sorted(slices.Values(req.Scopes))),
+		ExpiresAt:  req.ExpiresAt,
+	}
+	if err := s.keys.Create(ctx, &key); err != nil {
+		return nil, writeError(err, "failed to create API key")
+	}
+
+	return &CreatedAPIKeyResponse{APIKeyResponse: s.toResponse(key), Key: secret}, nil
+}
+
+// ListAPIKeys returns every key, newest first, revoked and expired ones included
+func (s *APIKeyService) ListAPIKeys(ctx context.Context) (_ []*APIKeyResponse, err error) {
+	ctx, end := startSpan(ctx, s.tracer, "APIKeyService.ListAPIKeys")
+	defer end(&err)
+
+	keys, err := s.keys.List(ctx)
+	if err != nil {
+		return nil, internalError("failed to retrieve API keys", err)
+	}
+	responses := make([]*APIKeyResponse, len(keys))
+	for i, key := range keys {
+		responses[i] = s.toResponse(key)
+	}
+	return responses, nil
+}
+
+// RevokeAPIKey revokes the key with the given ID or prefix; it stops working
+// immediately
+func (s *APIKeyService) RevokeAPIKey(ctx context.Context, idOrPrefix string) (err error) {
+	ctx, end := startSpan(ctx, s.tracer, "APIKeyService.RevokeAPIKey")
+	defer end(&err)
+
+	var key *models.APIKey
+	if id, parseErr := uuid.Parse(idOrPrefix); parseErr == nil {
+		key, err = s.keys.Get(ctx, id)
+	} else {
+		key, err = s.keys.GetByPrefix(ctx, idOrPrefix)
+	}
+	if err == nil {
+		err = s.keys.Revoke(ctx, key.ID, time.Now())
+	}
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return notFoundError("api_key_not_found", "API key not found or already revoked")
+		}
+		return internalError("failed to revoke API key", err)
+	}
+	return nil
+}
+
+// Authenticate resolves an X-API-Key value to its caller and records the
+// use. Errors for unknown, expired and revoked keys match auth.ErrInvalidAPIKey.
+func (s *APIKeyService) Authenticate(ctx context.Context, secret string) (_ auth.Principal, err error) {
+	ctx, end := startSpan(ctx, s.tracer, "APIKeyService.Authenticate")
+	defer end(&err)
+
+	now := time.Now()
+	key, err := s.keys.GetBySecretHash(ctx, auth.HashSecret(secret))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return auth.Principal{}, invalidAPIKeyError()
+		}
+		return auth.Principal{}, internalError("failed to look up API key", err)
+	}
+	if !key.Active(now) {
+		return auth.Principal{}, invalidAPIKeyError()
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= lastUsedResolution {
+		if err := s.keys.MarkUsed(ctx, key.ID, now); err != nil {
+			return auth.Principal{}, internalError("failed to record API key use", err)
+		}
+	}
+	return auth.Principal{APIKeyID: key.ID, Scopes: key.Scopes}, nil
+}
+
+func invalidAPIKeyError() *Error {
+	return &Error{Kind: ErrUnauthorized, Code: CodeInvalidAPIKey, Message: auth.ErrInvalidAPIKey.Error(), Cause: auth.ErrInvalidAPIKey}
+}
+
+// Business logic validation
+func (s *APIKeyService) validateCreateAPIKey(req CreateAPIKeyRequest) error {
+	var errs fieldErrors
+	if strings.TrimSpace(req.Name) == "" {
+		errs.add("name", "required", "API key name is required")
+	}
+	if len(req.Scopes) == 0 {
+		errs.add("scopes", "required", "at least one scope is required")
+	}
+	for _, scope := range req.Scopes {
+		if !scope.Valid() {
+			errs.add("scopes", "invalid", fmt.Sprintf("unknown scope %q", scope))
+			break
+		}
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		errs.add("expires_at", "in_past", "expiry must be in the future")
+	}
+	return errs.err()
+}
+
+// Transform model to response DTO
+func (s *APIKeyService) toResponse(key models.APIKey) *APIKeyResponse {
+	return &APIKeyResponse{
+		ID:         key.ID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     key.Scopes,
+		ExpiresAt:  key.ExpiresAt,
+		LastUsedAt: key.LastUsedAt,
+		RevokedAt:  key.RevokedAt,
+		CreatedAt:  key.CreatedAt,
+	}
+}
@@ -0,0 +1,272 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/mail"
+	"strings"
+	"sync"
+	"time"
+
+	"gmdb/auth"
+	"gmdb/models"
+	"gmdb/repository"
+	"gmdb/utils"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Password length bounds; bcrypt ignores bytes past the 72nd
+const (
+	MinPasswordLength = 12
+	MaxPasswordLength = 72
+)
+
+// Authentication error codes
+const (
+	CodeInvalidCredentials  = "invalid_credentials"
+	CodeInvalidRefreshToken = "invalid_refresh_token"
+)
+
+// dummyPasswordHash is compared against when the email is unknown, so login
+// takes as long for unknown users as for wrong passwords
+var dummyPasswordHash = sync.OnceValue(func() []byte {
+	hash, _ := bcrypt.GenerateFromPassword([]byte("gmdb-timing-equaliser"), bcrypt.DefaultCost)
+	return hash
+})
+
+type AuthService struct {
+	tracer        trace.Tracer
+	users         repository.UserRepository
+	refreshTokens repository.RefreshTokenRepository
+	tokens        *auth.Tokens
+}
+
+// NewAuthService creates a new auth service instance
+func NewAuthService(users repository.UserRepository, refreshTokens repository.RefreshTokenRepository, tokens *auth.Tokens, tracer trace.Tracer) *AuthService {
+	return &AuthService{tracer: tracer, users: users, refreshTokens: refreshTokens, tokens: tokens}
+}
+
+// CreateUserRequest represents the input for creating a user
+type CreateUserRequest struct {
+	Email    string      `json:"email"`
+	Password string      `json:"password"`
+	Role     models.Role `json:"role"`
+}
+
+// UserResponse represents the output format for a user
+type UserResponse struct {
+	ID        uuid.UUID   `json:"id"`
+	Email     string      `json:"email"`
+	Role      models.Role `json:"role"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+}
+
+// LoginRequest represents the input for a password login
+type LoginRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// RefreshRequest carries a refresh token to exchange or revoke
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// TokenResponse is a fresh access token and the refresh token to renew it
+type TokenResponse struct {
+	AccessToken  string      `json:"access_token"`
+	TokenType    string      `json:"token_type"`
+	ExpiresIn    int         `json:"expires_in"` // seconds
+	RefreshToken string      `json:"refresh_token"`
+	Role         models.Role `json:"role"`
+}
+
+// CreateUser registers a user with a bcrypt-hashed password
+func (s *AuthService) CreateUser(ctx context.Context, req CreateUserRequest) (_ *UserResponse, err error) {
+	ctx, end := startSpan(ctx, s.tracer, "AuthService.CreateUser")
+	defer end(&err)
+
+	req.Email = normalizeEmail(req.Email)
+	if err := s.validateCreateUser(req); err != nil {
+		return nil, err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, internalError("failed to hash password", err)
+	}
+
+	user := models.User{
+		ID:           utils.NewUUIDv7(),
+		Email:        req.Email,
+		PasswordHash: string(hash),
+		Role:         req.Role,
+	}
+	if err := s.users.Create(ctx, &user); err != nil {
+		if errors.Is(err, repository.ErrConflict) {
+			return nil, conflictError("email_taken", "a user with this email already exists")
+		}
+		return nil, internalError("failed to create user", err)
+	}
+
+	return &UserResponse{
+		ID:        user.ID,
+		Email:     user.Email,
+		Role:      user.Role,
+		CreatedAt: user.CreatedAt,
+		UpdatedAt: user.UpdatedAt,
+	}, nil
+}
+
+// Login checks an email and password and issues tokens. Unknown emails and
+// wrong passwords fail alike, so logins cannot probe which emails exist.
+func (s *AuthService) Login(ctx context.Context, req LoginRequest) (_ *TokenResponse, err error) {
+	ctx, end := startSpan(ctx, s.tracer, "AuthService.Login")
+	defer end(&err)
+
+	if err := s.validateLogin(req); err != nil {
+		return nil, err
+	}
+
+	user, err := s.users.GetByEmail(ctx, normalizeEmail(req.Email))
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return nil, internalError("failed to retrieve user", err)
+	}
+
+	hash := dummyPasswordHash()
+	if user != nil {
+		hash = []byte(user.PasswordHash)
+	}
+	if bcrypt.CompareHashAndPassword(hash, []byte(req.Password)) != nil || user == nil {
+		return nil, unauthorizedError(CodeInvalidCredentials, "invalid email or password")
+	}
+
+	return s.issueTokens(ctx, user)
+}
+
+// Refresh exchanges a refresh token for new tokens. Each refresh token works
+// once; the role is read again, so role changes apply on the next refresh.
+func (s *AuthService) Refresh(ctx context.Context, req RefreshRequest) (_ *TokenResponse, err error) {
+	ctx, end := startSpan(ctx, s.tracer, "AuthService.Refresh")
+	defer end(&err)
+
+	if err := s.validateRefresh(req); err != nil {
+		return nil, err
+	}
+
+	token, err := s.refreshTokens.Consume(ctx, auth.HashSecret(req.RefreshToken), time.Now())
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, unauthorizedError(CodeInvalidRefreshToken, "refresh token is invalid, expired or already used")
+		}
+		return nil, internalError("failed to redeem refresh token", err)
+	}
+
+	user, err := s.users.Get(ctx, token.UserID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, unauthorizedError(CodeInvalidRefreshToken, "refresh token is invalid, expired or already used")
+		}
+		return nil, internalError("failed to retrieve user", err)
+	}
+
+	return s.issueTokens(ctx, user)
+}
+
+// Logout revokes a refresh token; unknown or already revoked tokens are not
+// an error. Access tokens stay valid until they expire.
+func (s *AuthService) Logout(ctx context.Context, req RefreshRequest) (err error) {
+	ctx, end := startSpan(ctx, s.tracer, "AuthService.Logout")
+	defer end(&err)
+
+	if err := s.validateRefresh(req); err != nil {
+		return err
+	}
+
+	_, err = s.refreshTokens.Consume(ctx, auth.HashSecret(req.RefreshToken), time.Now())
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return internalError("failed to revoke refresh token", err)
+	}
+	return nil
+}
+
+// issueTokens signs an access token and stores a new refresh token for user
+func (s *AuthService) issueTokens(ctx context.Context, user *models.User) (*TokenResponse, error) {
+	access, expiresAt, err := s.tokens.Issue(user.ID, user.Role)
+	if err != nil {
+		return nil, internalError("failed to issue access token", err)
+	}
+
+	secret, err := auth.GenerateSecret()
+	if err != nil {
+		return nil, internalError("failed to issue refresh token", err)
+	}
+	refresh := models.RefreshToken{
+		ID:        utils.NewUUIDv7(),
+		UserID:    user.ID,
+		TokenHash: auth.HashSecret(secret),
+		ExpiresAt: time.Now().Add(s.tokens.RefreshTTL()),
+	}
+	if err := s.refreshTokens.Create(ctx, &refresh); err != nil {
+		return nil, internalError("failed to store refresh token", err)
+	}
+
+	return &TokenResponse{
+		AccessToken:  access,
+		TokenType:    "Bearer",
+		ExpiresIn:    int(time.Until(expiresAt).Round(time.Second).Seconds()),
+		RefreshToken: secret,
+		Role:         user.Role,
+	}, nil
+}
+
+// Business logic validation
+func (s *AuthService) validateCreateUser(req CreateUserRequest) error {
+	var errs fieldErrors
+	if req.Email == "" {
+		errs.add("email", "required", "email is required")
+	} else if addr, err := mail.ParseAddress(req.Email); err != nil || addr.Address != req.Email {
+		errs.add("email", "invalid", "email must be a plain address such as ada@example.com")
+	}
+	if req.Password == "" {
+		errs.add("password", "required", "password is required")
+	} else if len(req.Password) < MinPasswordLength {
+		errs.add("password", "too_short", "password must be at least 12 characters")
+	} else if len(req.Password) > MaxPasswordLength {
+		errs.add("password", "too_long", "password must be at most 72 bytes")
+	}
+	if req.Role == "" {
+		errs.add("role", "required", "role is required")
+	} else if !req.Role.Valid() {
+		errs.add("role", "invalid", "role must be reader, editor or admin")
+	}
+	return errs.err()
+}
+
+func (s *AuthService) validateLogin(req LoginRequest) error {
+	var errs fieldErrors
+	if strings.TrimSpace(req.Email) == "" {
+		errs.add("email", "required", "email is required")
+	}
+	if req.Password == "" {
+		errs.add("password", "required", "password is required")
+	}
+	return errs.err()
+}
+
+func (s *AuthService) validateRefresh(req RefreshRequest) error {
+	var errs fieldErrors
+	if req.RefreshToken == "" {
+		errs.add("refresh_token", "required", "refresh token is required")
+	}
+	return errs.err()
+}
+
+// normalizeEmail makes email lookups case-insensitive
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
@@ -1,4 +1,407 @@
 package services
 
-// TODO: Implement award service
-// This will handle business logic for awards CRUD operations
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"gmdb/models"
+	"gmdb/repository"
+	"gmdb/utils"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// minAwardYear is the earliest year accepted for an award
+const minAwardYear = 1900
+
+type AwardService struct {
+	tracer trace.Tracer
+	awards repository.AwardRepository
+	movies repository.MovieRepository
+	actors repository.ActorRepository
+}
+
+// NewAwardService creates a new award service instance; movies and actors
+// are used to check that award recipients exist
+func NewAwardService(awards repository.AwardRepository, movies repository.MovieRepository, actors repository.ActorRepository, tracer trace.Tracer) *AwardService {
+	return &AwardService{tracer: tracer, awards: awards, movies: movies, actors: actors}
+}
+
+// awardListSpec is the allow-list of award filters and sort fields
+var awardListSpec = listSpec[models.Award]{
+	filters: map[string]filterSpec{
+		"name_contains": {"name", repository.OpContains, parseString},
+		"category":      {"category", repository.OpEqFold, parseString},
+		"year":          {"year", repository.OpEq, parseInt},
+		"year_gte":      {"year", repository.OpGte, parseInt},
+		"year_lte":      {"year", repository.OpLte, parseInt},
+		"movie_id":      {"movie_id", repository.OpEq, parseUUID},
+		"actor_id":      {"actor_id", repository.OpEq, parseUUID},
+	},
+	sorts:  []string{"name", "category", "year", "created_at"},
+	fields: repository.AwardFields,
+	id:     func(a models.Award) uuid.UUID { return a.ID },
+}
+
+// CreateAwardRequest represents the input for creating or updating an award
+// An award goes to exactly one recipient: a movie or an actor, never both
+type CreateAwardRequest struct {
+	Name        string     `json:"name"`
+	Category    string     `json:"category"`
+	Year        int        `json:"year"`
+	MovieID     *uuid.UUID `json:"movie_id"`
+	ActorID     *uuid.UUID `json:"actor_id"`
+	Description string     `json:"description"`
+}
+
+// AwardRecipient is a single award within a grouped create request
+type AwardRecipient struct {
+	Name        string     `json:"name"`
+	MovieID     *uuid.UUID `json:"movie_id"`
+	ActorID     *uuid.UUID `json:"actor_id"`
+	Description string     `json:"description"`
+}
+
+// CreateAwardGroupRequest creates several awards sharing a year and category
+type CreateAwardGroupRequest struct {
+	Year       int              `json:"year"`
+	Category   string           `json:"category"`
+	Name       string           `json:"name"`
+	Recipients []AwardRecipient `json:"recipients"`
+}
+
+// AwardResponse represents the output format for an award
+type AwardResponse struct {
+	ID          uuid.UUID  `json:"id"`
+	Name        string     `json:"name"`
+	Category    string     `json:"category"`
+	Year        int        `json:"year"`
+	MovieID     *uuid.UUID `json:"movie_id"`
+	ActorID     *uuid.UUID `json:"actor_id"`
+	Description string     `json:"description"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// AwardCategoryGroup holds the awards of one category within a year
+type AwardCategoryGroup struct {
+	Category string           `json:"category"`
+	Awards   []*AwardResponse `json:"awards"`
+}
+
+// AwardYearGroup holds the award categories of one year
+type AwardYearGroup struct {
+	Year       int                   `json:"year"`
+	Categories []*AwardCategoryGroup `json:"categories"`
+}
+
+// CreateAward handles the business logic for creating a new award
+func (s *AwardService) CreateAward(ctx context.Context, req CreateAwardRequest) (_ *AwardResponse, err error) {
+	ctx, end := startSpan(ctx, s.tracer, "AwardService.CreateAward")
+	defer end(&err)
+
+	// Business validation
+	var errs fieldErrors
+	if err := s.validateCreateAward(ctx, req, "", &errs); err != nil {
+		return nil, err
+	}
+	if err := errs.err(); err != nil {
+		return nil, err
+	}
+
+	award := s.newAward(req)
+
+	// Save to database
+	if err := s.awards.Create(ctx, &award); err != nil {
+		return nil, writeError(err, "failed to create award")
+	}
+
+	return s.toResponse(award), nil
+}
+
+// CreateAwardGroup creates all awards of a year/category, all or nothing
+func (s *AwardService) CreateAwardGroup(ctx context.Context, req CreateAwardGroupRequest) (_ *AwardYearGroup, err error) {
+	ctx, end := startSpan(ctx, s.tracer, "AwardService.CreateAwardGroup")
+	defer end(&err)
+
+	var errs fieldErrors
+	if req.Year == 0 {
+		errs.add("year", "required", "award year is required")
+	}
+	if strings.TrimSpace(req.Category) == "" {
+		errs.add("category", "required", "award category is required")
+	}
+	if len(req.Recipients) == 0 {
+		errs.add("recipients", "required", "at least one recipient is required")
+	}
+
+	var awards []models.Award
+	for i, recipient := range req.Recipients {
+		name := recipient.Name
+		if name == "" {
+			name = req.Name
+		}
+		awardReq := CreateAwardRequest{
+			Name:        name,
+			Category:    req.Category,
+			Year:        req.Year,
+			MovieID:     recipient.MovieID,
+			ActorID:     recipient.ActorID,
+			Description: recipient.Description,
+		}
+		prefix := fmt.Sprintf("recipients[%d].", i)
+		if err := s.validateCreateAward(ctx, awardReq, prefix, &errs); err != nil {
+			return nil, err
+		}
+		awards = append(awards, s.newAward(awardReq))
+	}
+	if err := errs.err(); err != nil {
+		return nil, err
+	}
+
+	if err := s.awards.CreateMany(ctx, awards); err != nil {
+		return nil, writeError(err, "failed to create awards")
+	}
+
+	groups := s.groupAwards(awards)
+	return groups[0], nil
+}
+
+// GetAward retrieves an award by ID
+func (s *AwardService) GetAward(ctx context.Context, id uuid.UUID) (_ *AwardResponse, err error) {
+	ctx, end := startSpan(ctx, s.tracer, "AwardService.GetAward")
+	defer end(&err)
+
+	award, err := s.findAward(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.toResponse(*award), nil
+}
+
+// GetAllAwards retrieves one page of awards matching the allow-listed filters and sort
+func (s *AwardService) GetAllAwards(ctx context.Context, q ListQuery) (_ *Page[*AwardResponse], err error) {
+	ctx, end := startSpan(ctx, s.tracer, "AwardService.GetAllAwards")
+	defer end(&err)
+
+	return listPage(ctx, awardListSpec, q, s.awards.List, s.toResponse, "failed to retrieve awards")
+}
+
+// groupedAwardSort orders awards the way groups are listed, so each page
+// continues where the previous one stopped
+const groupedAwardSort = "-year,category,name"
+
+// GetAwardsGrouped retrieves one page of awards grouped by year (newest
+// first) and category. Pages hold up to q.Page.Limit awards, so a year or
+// category can continue on the next page; Total counts awards, not groups.
+func (s *AwardService) GetAwardsGrouped(ctx context.Context, q ListQuery) (_ *Page[*AwardYearGroup], err error) {
+	ctx, end := startSpan(ctx, s.tracer, "AwardService.GetAwardsGrouped")
+	defer end(&err)
+
+	if q.Sort != "" {
+		var errs fieldErrors
+		errs.add("sort", "unknown_field", "grouped awards are always sorted by year, category and name")
+		return nil, errs.err()
+	}
+	q.Sort = groupedAwardSort
+
+	awards, err := listPage(ctx, awardListSpec, q, s.awards.List, func(a models.Award) models.Award { return a }, "failed to retrieve awards")
+	if err != nil {
+		return nil, err
+	}
+
+	return &Page[*AwardYearGroup]{
+		Items:      s.groupAwards(awards.Items),
+		Limit:      awards.Limit,
+		NextCursor: awards.NextCursor,
+		Total:      awards.Total,
+	}, nil
+}
+
+// UpdateAward updates an existing award
+func (s *AwardService) UpdateAward(ctx context.Context, id uuid.UUID, req CreateAwardRequest) (_ *AwardResponse, err error) {
+	ctx, end := startSpan(ctx, s.tracer, "AwardService.UpdateAward")
+	defer end(&err)
+
+	// Check if award exists
+	award, err := s.findAward(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Business validation
+	var errs fieldErrors
+	if err := s.validateCreateAward(ctx, req, "", &errs); err != nil {
+		return nil, err
+	}
+	if err := errs.err(); err != nil {
+		return nil, err
+	}
+
+	// Update fields
+	award.Name = strings.TrimSpace(req.Name)
+	award.Category = req.Category
+	award.Year = req.Year
+	award.MovieID = req.MovieID
+	award.ActorID = req.ActorID
+	award.Description = req.Description
+
+	if err := s.awards.Update(ctx, award); err != nil {
+		return nil, writeError(err, "failed to update award")
+	}
+
+	return s.toResponse(*award), nil
+}
+
+// DeleteAward soft deletes an award
+func (s *AwardService) DeleteAward(ctx context.Context, id uuid.UUID) (err error) {
+	ctx, end := startSpan(ctx, s.tracer, "AwardService.DeleteAward")
+	defer end(&err)
+
+	if err := s.awards.Delete(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return notFoundError("award_not_found", "award not found")
+		}
+		return internalError("failed to delete award", err)
+	}
+	return nil
+}
+
+// RestoreAward undeletes a soft-deleted award and returns it
+func (s *AwardService) RestoreAward(ctx context.Context, id uuid.UUID) (_ *AwardResponse, err error) {
+	ctx, end := startSpan(ctx, s.tracer, "AwardService.RestoreAward")
+	defer end(&err)
+
+	if err := s.awards.Restore(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, notFoundError("award_not_found", "deleted award not found")
+		}
+		return nil, internalError("failed to restore award", err)
+	}
+
+	award, err := s.findAward(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.toResponse(*award), nil
+}
+
+// findAward loads an award, mapping a missing row to award_not_found
+func (s *AwardService) findAward(ctx context.Context, id uuid.UUID) (*models.Award, error) {
+	award, err := s.awards.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, notFoundError("award_not_found", "award not found")
+		}
+		return nil, internalError("failed to retrieve award", err)
+	}
+	return award, nil
+}
+
+// Business logic validation.
+// Violations are collected into errs with field names prefixed by prefix;
+// the returned error is only set when a lookup itself fails.
+func (s *AwardService) validateCreateAward(ctx context.Context, req CreateAwardRequest, prefix string, errs *fieldErrors) error {
+	if strings.TrimSpace(req.Name) == "" {
+		errs.add(prefix+"name", "required", "award name is required")
+	}
+	if req.Year != 0 && (req.Year < minAwardYear || req.Year > time.Now().Year()+1) {
+		errs.add(prefix+"year", "out_of_range", "award year is out of range")
+	}
+	if req.MovieID == nil && req.ActorID == nil {
+		errs.add(prefix+"movie_id", "required_without", "award must have a movie or an actor recipient")
+		errs.add(prefix+"actor_id", "required_without", "award must have a movie or an actor recipient")
+	}
+	if req.MovieID != nil && req.ActorID != nil {
+		errs.add(prefix+"actor_id", "excluded_with", "award cannot have both a movie and an actor recipient")
+	}
+
+	// Referenced rows must exist and not be soft deleted
+	if req.MovieID != nil {
+		if _, err := s.movies.Get(ctx, *req.MovieID); err != nil {
+			if !errors.Is(err, repository.ErrNotFound) {
+				return internalError("failed to retrieve award movie", err)
+			}
+			errs.add(prefix+"movie_id", "not_found", "award movie does not exist")
+		}
+	}
+	if req.ActorID != nil {
+		if _, err := s.actors.Get(ctx, *req.ActorID); err != nil {
+			if !errors.Is(err, repository.ErrNotFound) {
+				return internalError("failed to retrieve award actor", err)
+			}
+			errs.add(prefix+"actor_id", "not_found", "award actor does not exist")
+		}
+	}
+	return nil
+}
+
+// newAward builds an award model from a validated request
+func (s *AwardService) newAward(req CreateAwardRequest) models.Award {
+	return models.Award{
+		ID:          utils.NewUUIDv7(),
+		Name:        strings.TrimSpace(req.Name),
+		Category:    req.Category,
+		Year:        req.Year,
+		MovieID:     req.MovieID,
+		ActorID:     req.ActorID,
+		Description: req.Description,
+	}
+}
+
+// groupAwards buckets awards by year (newest first), then by category
+func (s *AwardService) groupAwards(awards []models.Award) []*AwardYearGroup {
+	years := make(map[int]*AwardYearGroup)
+	categories := make(map[int]map[string]*AwardCategoryGroup)
+	var groups []*AwardYearGroup
+
+	for _, award := range awards {
+		yearGroup, ok := years[award.Year]
+		if !ok {
+			yearGroup = &AwardYearGroup{Year: award.Year}
+			years[award.Year] = yearGroup
+			categories[award.Year] = make(map[string]*AwardCategoryGroup)
+			groups = append(groups, yearGroup)
+		}
+
+		categoryGroup, ok := categories[award.Year][award.Category]
+		if !ok {
+			categoryGroup = &AwardCategoryGroup{Category: award.Category}
+			categories[award.Year][award.Category] = categoryGroup
+			yearGroup.Categories = append(yearGroup.Categories, categoryGroup)
+		}
+		categoryGroup.Awards = append(categoryGroup.Awards, s.toResponse(award))
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].Year > groups[j].Year
+	})
+	for _, group := range groups {
+		sort.SliceStable(group.Categories, func(i, j int) bool {
+			return group.Categories[i].Category < group.Categories[j].Category
+		})
+	}
+
+	return groups
+}
+
+// Transform model to response DTO
+func (s *AwardService) toResponse(award models.Award) *AwardResponse {
+	return &AwardResponse{
+		ID:          award.ID,
+		Name:        award.Name,
+		Category:    award.Category,
+		Year:        award.Year,
+		MovieID:     award.MovieID,
+		ActorID:     award.ActorID,
+		Description: award.Description,
+		CreatedAt:   award.CreatedAt,
+		UpdatedAt:   award.UpdatedAt,
+	}
+}
@@ -0,0 +1,230 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"gmdb/models"
+	"gmdb/repository"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// CastService manages the movie_actors join table
+type CastService struct {
+	tracer    trace.Tracer
+	credits   repository.MovieRepository // join rows and batch movie loads
+	actorRepo repository.ActorRepository // batch actor loads
+	movies    *MovieService
+	actors    *ActorService
+}
+
+// NewCastService creates a new cast service instance; movies and actors are
+// the shared services used to look up and render both sides of a credit
+func NewCastService(credits repository.MovieRepository, actorRepo repository.ActorRepository, movies *MovieService, actors *ActorService, tracer trace.Tracer) *CastService {
+	return &CastService{
+		tracer:    tracer,
+		credits:   credits,
+		actorRepo: actorRepo,
+		movies:    movies,
+		actors:    actors,
+	}
+}
+
+// AddCastMemberRequest represents the input for adding an actor to a movie
+type AddCastMemberRequest struct {
+	ActorID       uuid.UUID         `json:"actor_id"`
+	CharacterName string            `json:"character_name"`
+	BillingOrder  int               `json:"billing_order"`
+	CreditType    models.CreditType `json:"credit_type"`
+}
+
+// CreditResponse holds the role an actor plays in a movie
+type CreditResponse struct {
+	CharacterName string            `json:"character_name"`
+	BillingOrder  int               `json:"billing_order"`
+	CreditType    models.CreditType `json:"credit_type"`
+}
+
+// CastMemberResponse is an actor as credited in a movie's cast
+type CastMemberResponse struct {
+	ActorResponse
+	CreditResponse
+}
+
+// FilmographyEntryResponse is a movie as credited in an actor's filmography
+type FilmographyEntryResponse struct {
+	MovieResponse
+	CreditResponse
+}
+
+// AddCastMember links an actor to a movie, or updates the credit if already linked
+func (s *CastService) AddCastMember(ctx context.Context, movieID uuid.UUID, req AddCastMemberRequest) (_ *CastMemberResponse, err error) {
+	ctx, end := startSpan(ctx, s.tracer, "CastService.AddCastMember")
+	defer end(&err)
+
+	if req.CreditType == "" {
+		req.CreditType = models.CreditSupporting
+	}
+	if err := s.validateAddCastMember(req); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.movies.findMovie(ctx, movieID); err != nil {
+		return nil, err
+	}
+	actor, err := s.actors.findActor(ctx, req.ActorID)
+	if err != nil {
+		return nil, err
+	}
+
+	link := models.MovieActor{
+		MovieID:       movieID,
+		ActorID:       req.ActorID,
+		CharacterName: strings.TrimSpace(req.CharacterName),
+		BillingOrder:  req.BillingOrder,
+		CreditType:    req.CreditType,
+	}
+	// Upsert keeps repeated requests idempotent
+	if err := s.credits.UpsertCredit(ctx, &link); err != nil {
+		return nil, writeError(err, "failed to add actor to movie")
+	}
+
+	return &CastMemberResponse{
+		ActorResponse:  *s.actors.toResponse(*actor),
+		CreditResponse: toCreditResponse(link),
+	}, nil
+}
+
+// RemoveCastMember unlinks an actor from a movie; removing a missing link is a no-op
+func (s *CastService) RemoveCastMember(ctx context.Context, movieID, actorID uuid.UUID) (err error) {
+	ctx, end := startSpan(ctx, s.tracer, "CastService.RemoveCastMember")
+	defer end(&err)
+
+	if _, err := s.movies.findMovie(ctx, movieID); err != nil {
+		return err
+	}
+	if _, err := s.actors.findActor(ctx, actorID); err != nil {
+		return err
+	}
+
+	if err := s.credits.DeleteCredit(ctx, movieID, actorID); err != nil {
+		return internalError("failed to remove actor from movie", err)
+	}
+	return nil
+}
+
+// GetMovieCast retrieves the live actors credited in a movie, in billing order
+func (s *CastService) GetMovieCast(ctx context.Context, movieID uuid.UUID) (_ []*CastMemberResponse, err error) {
+	ctx, end := startSpan(ctx, s.tracer, "CastService.GetMovieCast")
+	defer end(&err)
+
+	if _, err := s.movies.findMovie(ctx, movieID); err != nil {
+		return nil, err
+	}
+
+	// Billed actors first by billing order, unbilled (0) actors last
+	links, err := s.credits.ListCredits(ctx, movieID)
+	if err != nil {
+		return nil, internalError("failed to retrieve movie cast", err)
+	}
+
+	actorIDs := make([]uuid.UUID, len(links))
+	for i, link := range links {
+		actorIDs[i] = link.ActorID
+	}
+
+	actors, err := s.actorRepo.GetMany(ctx, actorIDs)
+	if err != nil {
+		return nil, internalError("failed to retrieve movie cast", err)
+	}
+	actorsByID := make(map[uuid.UUID]models.Actor, len(actors))
+	for _, actor := range actors {
+		actorsByID[actor.ID] = actor
+	}
+
+	// Walk the links to keep billing order; soft-deleted actors are skipped
+	responses := make([]*CastMemberResponse, 0, len(actors))
+	for _, link := range links {
+		actor, ok := actorsByID[link.ActorID]
+		if !ok {
+			continue
+		}
+		responses = append(responses, &CastMemberResponse{
+			ActorResponse:  *s.actors.toResponse(actor),
+			CreditResponse: toCreditResponse(link),
+		})
+	}
+	return responses, nil
+}
+
+// GetActorMovies retrieves the live movies an actor is credited in, oldest first
+func (s *CastService) GetActorMovies(ctx context.Context, actorID uuid.UUID) (_ []*FilmographyEntryResponse, err error) {
+	ctx, end := startSpan(ctx, s.tracer, "CastService.GetActorMovies")
+	defer end(&err)
+
+	if _, err := s.actors.findActor(ctx, actorID); err != nil {
+		return nil, err
+	}
+
+	links, err := s.credits.ListCreditsByActor(ctx, actorID)
+	if err != nil {
+		return nil, internalError("failed to retrieve actor movies", err)
+	}
+
+	movieIDs := make([]uuid.UUID, len(links))
+	linksByMovie := make(map[uuid.UUID]models.MovieActor, len(links))
+	for i, link := range links {
+		movieIDs[i] = link.MovieID
+		linksByMovie[link.MovieID] = link
+	}
+
+	movies, err := s.credits.GetMany(ctx, movieIDs)
+	if err != nil {
+		return nil, internalError("failed to retrieve actor movies", err)
+	}
+	sort.SliceStable(movies, func(i, j int) bool {
+		if movies[i].Year != movies[j].Year {
+			return movies[i].Year < movies[j].Year
+		}
+		return movies[i].Title < movies[j].Title
+	})
+
+	responses := make([]*FilmographyEntryResponse, len(movies))
+	for i, movie := range movies {
+		responses[i] = &FilmographyEntryResponse{
+			MovieResponse:  *s.movies.toResponse(movie),
+			CreditResponse: toCreditResponse(linksByMovie[movie.ID]),
+		}
+	}
+	return responses, nil
+}
+
+// Business logic validation
+func (s *CastService) validateAddCastMember(req AddCastMemberRequest) error {
+	var errs fieldErrors
+	if req.ActorID == uuid.Nil {
+		errs.add("actor_id", "required", "actor_id is required")
+	}
+	if req.BillingOrder < 0 {
+		errs.add("billing_order", "out_of_range", "billing order cannot be negative")
+	}
+	if !req.CreditType.Valid() {
+		errs.add("credit_type", "invalid_choice", "credit type must be one of lead, supporting, cameo, voice")
+	}
+	if len(req.CharacterName) > 255 {
+		errs.add("character_name", "too_long", "character name must be at most 255 characters")
+	}
+	return errs.err()
+}
+
+// Transform join row to credit DTO
+func toCreditResponse(link models.MovieActor) CreditResponse {
+	return CreditResponse{
+		CharacterName: link.CharacterName,
+		BillingOrder:  link.BillingOrder,
+		CreditType:    link.CreditType,
+	}
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"errors"
+
+	"gmdb/repository"
+	"gmdb/utils"
+)
+
+// Error kinds returned by every service; match them with errors.Is
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrValidation   = errors.New("validation failed")
+	ErrConflict     = errors.New("conflict")
+	ErrUnauthorized = errors.New("unauthorized") // credentials were rejected
+	ErrInternal     = errors.New("internal error")
+)
+
+// Error codes shared by every resource
+const (
+	CodeInternal   = utils.CodeInternal
+	CodeConflict   = "conflict"
+	CodeValidation = "validation_failed"
+)
+
+// Error is a domain error with a kind, a stable machine-readable code and
+// a client-safe message. Use errors.As to read the code.
+type Error struct {
+	Kind    error  // one of ErrNotFound, ErrValidation, ErrConflict, ErrUnauthorized, ErrInternal
+	Code    string // stable identifier, e.g. "actor_not_found"
+	Message string
+	Details []utils.FieldError // every invalid field, for validation errors
+	Cause   error              // underlying error, never shown to clients
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Unwrap exposes both the kind and the cause to errors.Is / errors.As
+func (e *Error) Unwrap() []error {
+	if e.Cause != nil {
+		return []error{e.Kind, e.Cause}
+	}
+	return []error{e.Kind}
+}
+
+func notFoundError(code, message string) *Error {
+	return &Error{Kind: ErrNotFound, Code: code, Message: message}
+}
+
+func conflictError(code, message string) *Error {
+	return &Error{Kind: ErrConflict, Code: code, Message: message}
+}
+
+func unauthorizedError(code, message string) *Error {
+	return &Error{Kind: ErrUnauthorized, Code: code, Message: message}
+}
+
+func internalError(message string, cause error) *Error {
+	return &Error{Kind: ErrInternal, Code: CodeInternal, Message: message, Cause: cause}
+}
+
+// fieldErrors collects every violation before a validation error is returned
+type fieldErrors []utils.FieldError
+
+func (f *fieldErrors) add(field, code, message string) {
+	*f = append(*f, utils.FieldError{Field: field, Code: code, Message: message})
+}
+
+// err returns nil when nothing was collected, otherwise a validation error
+func (f fieldErrors) err() error {
+	if len(f) == 0 {
+		return nil
+	}
+	message := f[0].Message
+	if len(f) > 1 {
+		message = "validation failed"
+	}
+	return &Error{Kind: ErrValidation, Code: CodeValidation, Message: message, Details: f}
+}
+
+// writeError classifies a failed write; repository conflicts become conflicts
+func writeError(err error, message string) *Error {
+	if errors.Is(err, repository.ErrConflict) {
+		return &Error{Kind: ErrConflict, Code: CodeConflict, Message: message, Cause: err}
+	}
+	return internalError(message, err)
+}
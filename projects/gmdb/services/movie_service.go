@@ -1,4 +1,235 @@
 package services
 
-// TODO: Implement movie service
-// This will handle business logic for movies CRUD operations
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"time"
+
+	"gmdb/models"
+	"gmdb/repository"
+	"gmdb/utils"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Bounds for movie validation
+const (
+	minMovieYear   = 1888 // Roundhay Garden Scene, the oldest surviving film
+	maxMovieRating = 10.0
+	minMovieRating = 0.0
+)
+
+type MovieService struct {
+	tracer trace.Tracer
+	movies repository.MovieRepository
+}
+
+// NewMovieService creates a new movie service instance
+func NewMovieService(movies repository.MovieRepository, tracer trace.Tracer) *MovieService {
+	return &MovieService{tracer: tracer, movies: movies}
+}
+
+// movieListSpec is the allow-list of movie filters and sort fields
+var movieListSpec = listSpec[models.Movie]{
+	filters: map[string]filterSpec{
+		"genre":          {"genre", repository.OpEqFold, parseString},
+		"director":       {"director", repository.OpEqFold, parseString},
+		"title_contains": {"title", repository.OpContains, parseString},
+		"year":           {"year", repository.OpEq, parseInt},
+		"year_gte":       {"year", repository.OpGte, parseInt},
+		"year_lte":       {"year", repository.OpLte, parseInt},
+		"rating_gte":     {"rating", repository.OpGte, parseFloat},
+		"rating_lte":     {"rating", repository.OpLte, parseFloat},
+	},
+	sorts:  []string{"title", "year", "rating", "created_at"},
+	fields: repository.MovieFields,
+	id:     func(m models.Movie) uuid.UUID { return m.ID },
+}
+
+// CreateMovieRequest represents the input for creating or updating a movie
+type CreateMovieRequest struct {
+	Title       string  `json:"title"`
+	Year        int     `json:"year"`
+	Director    string  `json:"director"`
+	Genre       string  `json:"genre"`
+	Description string  `json:"description"`
+	Rating      float64 `json:"rating"`
+}
+
+// MovieResponse represents the output format for a movie
+type MovieResponse struct {
+	ID          uuid.UUID `json:"id"`
+	Title       string    `json:"title"`
+	Year        int       `json:"year"`
+	Director    string    `json:"director"`
+	Genre       string    `json:"genre"`
+	Description string    `json:"description"`
+	Rating      float64   `json:"rating"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// CreateMovie handles the business logic for creating a new movie
+func (s *MovieService) CreateMovie(ctx context.Context, req CreateMovieRequest) (_ *MovieResponse, err error) {
+	ctx, end := startSpan(ctx, s.tracer, "MovieService.CreateMovie")
+	defer end(&err)
+
+	// Business validation
+	if err := s.validateCreateMovie(req); err != nil {
+		return nil, err
+	}
+
+	// Create movie model
+	movie := models.Movie{
+		ID:          utils.NewUUIDv7(),
+		Title:       strings.TrimSpace(req.Title),
+		Year:        req.Year,
+		Director:    req.Director,
+		Genre:       req.Genre,
+		Description: req.Description,
+		Rating:      roundRating(req.Rating),
+	}
+
+	// Save to database
+	if err := s.movies.Create(ctx, &movie); err != nil {
+		return nil, writeError(err, "failed to create movie")
+	}
+
+	// Transform to response
+	return s.toResponse(movie), nil
+}
+
+// GetMovie retrieves a movie by ID
+func (s *MovieService) GetMovie(ctx context.Context, id uuid.UUID) (_ *MovieResponse, err error) {
+	ctx, end := startSpan(ctx, s.tracer, "MovieService.GetMovie")
+	defer end(&err)
+
+	movie, err := s.findMovie(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.toResponse(*movie), nil
+}
+
+// GetAllMovies retrieves one page of movies matching the allow-listed filters and sort
+func (s *MovieService) GetAllMovies(ctx context.Context, q ListQuery) (_ *Page[*MovieResponse], err error) {
+	ctx, end := startSpan(ctx, s.tracer, "MovieService.GetAllMovies")
+	defer end(&err)
+
+	return listPage(ctx, movieListSpec, q, s.movies.List, s.toResponse, "failed to retrieve movies")
+}
+
+// UpdateMovie updates an existing movie
+func (s *MovieService) UpdateMovie(ctx context.Context, id uuid.UUID, req CreateMovieRequest) (_ *MovieResponse, err error) {
+	ctx, end := startSpan(ctx, s.tracer, "MovieService.UpdateMovie")
+	defer end(&err)
+
+	// Check if movie exists
+	movie, err := s.findMovie(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Business validation
+	if err := s.validateCreateMovie(req); err != nil {
+		return nil, err
+	}
+
+	// Update fields
+	movie.Title = strings.TrimSpace(req.Title)
+	movie.Year = req.Year
+	movie.Director = req.Director
+	movie.Genre = req.Genre
+	movie.Description = req.Description
+	movie.Rating = roundRating(req.Rating)
+
+	if err := s.movies.Update(ctx, movie); err != nil {
+		return nil, writeError(err, "failed to update movie")
+	}
+
+	return s.toResponse(*movie), nil
+}
+
+// DeleteMovie soft deletes a movie
+func (s *MovieService) DeleteMovie(ctx context.Context, id uuid.UUID) (err error) {
+	ctx, end := startSpan(ctx, s.tracer, "MovieService.DeleteMovie")
+	defer end(&err)
+
+	if err := s.movies.Delete(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return notFoundError("movie_not_found", "movie not found")
+		}
+		return internalError("failed to delete movie", err)
+	}
+	return nil
+}
+
+// RestoreMovie undeletes a soft-deleted movie and returns it
+func (s *MovieService) RestoreMovie(ctx context.Context, id uuid.UUID) (_ *MovieResponse, err error) {
+	ctx, end := startSpan(ctx, s.tracer, "MovieService.RestoreMovie")
+	defer end(&err)
+
+	if err := s.movies.Restore(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, notFoundError("movie_not_found", "deleted movie not found")
+		}
+		return nil, internalError("failed to restore movie", err)
+	}
+
+	movie, err := s.findMovie(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.toResponse(*movie), nil
+}
+
+// findMovie loads a movie, mapping a missing row to movie_not_found
+func (s *MovieService) findMovie(ctx context.Context, id uuid.UUID) (*models.Movie, error) {
+	movie, err := s.movies.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, notFoundError("movie_not_found", "movie not found")
+		}
+		return nil, internalError("failed to retrieve movie", err)
+	}
+	return movie, nil
+}
+
+// Business logic validation
+func (s *MovieService) validateCreateMovie(req CreateMovieRequest) error {
+	var errs fieldErrors
+	if strings.TrimSpace(req.Title) == "" {
+		errs.add("title", "required", "movie title is required")
+	}
+	if req.Year != 0 && (req.Year < minMovieYear || req.Year > time.Now().Year()+10) {
+		errs.add("year", "out_of_range", "movie year is out of range")
+	}
+	if req.Rating < minMovieRating || req.Rating > maxMovieRating {
+		errs.add("rating", "out_of_range", "movie rating must be between 0 and 10")
+	}
+	return errs.err()
+}
+
+// roundRating rounds a rating to one decimal place to fit decimal(3,1)
+func roundRating(rating float64) float64 {
+	return math.Round(rating*10) / 10
+}
+
+// Transform model to response DTO
+func (s *MovieService) toResponse(movie models.Movie) *MovieResponse {
+	return &MovieResponse{
+		ID:          movie.ID,
+		Title:       movie.Title,
+		Year:        movie.Year,
+		Director:    movie.Director,
+		Genre:       movie.Genre,
+		Description: movie.Description,
+		Rating:      movie.Rating,
+		CreatedAt:   movie.CreatedAt,
+		UpdatedAt:   movie.UpdatedAt,
+	}
+}
@@ -0,0 +1,125 @@
+package services
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"reflect"
+	"strconv"
+
+	"gmdb/repository"
+
+	"github.com/google/uuid"
+)
+
+// Page size bounds shared by every list endpoint
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// PageRequest describes which page of a list to return
+type PageRequest struct {
+	Limit        int
+	IncludeTotal bool
+	cursor       *pageCursor // decoded cursor; nil means the first page
+}
+
+// Page is one page of a list plus what is needed to fetch the next one
+type Page[T any] struct {
+	Items      []T
+	Limit      int
+	NextCursor string // empty on the last page
+	Total      *int64 // only set when requested
+}
+
+// pageCursor is the opaque cursor payload: the sort it was issued for, the
+// last row's sort values and its ID (UUIDv7, so time-ordered) as tiebreaker
+type pageCursor struct {
+	Sort   string            `json:"s,omitempty"`
+	Values []json.RawMessage `json:"v,omitempty"`
+	ID     uuid.UUID         `json:"id"`
+}
+
+// ParsePageRequest validates the raw limit, cursor and include_total query values
+func ParsePageRequest(limit, cursor, includeTotal string) (PageRequest, error) {
+	req := PageRequest{Limit: DefaultPageSize}
+	var errs fieldErrors
+
+	if limit != "" {
+		n, err := strconv.Atoi(limit)
+		switch {
+		case err != nil:
+			errs.add("limit", "invalid_type", "limit must be an integer")
+		case n < 1 || n > MaxPageSize:
+			errs.add("limit", "out_of_range", "limit must be between 1 and "+strconv.Itoa(MaxPageSize))
+		default:
+			req.Limit = n
+		}
+	}
+
+	if cursor != "" {
+		decoded, err := decodeCursor(cursor)
+		if err != nil {
+			errs.add("cursor", "invalid", "cursor is invalid")
+		}
+		req.cursor = decoded
+	}
+
+	if includeTotal != "" {
+		b, err := strconv.ParseBool(includeTotal)
+		if err != nil {
+			errs.add("include_total", "invalid_type", "include_total must be a boolean")
+		}
+		req.IncludeTotal = b
+	}
+
+	return req, errs.err()
+}
+
+// cursorAfter converts a decoded cursor into typed repository values for keys.
+// sort identifies the ordering so cursors from another ordering are rejected.
+func cursorAfter[M any](c *pageCursor, sort string, keys []repository.SortKey, fields map[string]repository.Field[M]) (*repository.Cursor, error) {
+	if c == nil {
+		return nil, nil
+	}
+
+	var errs fieldErrors
+	if c.Sort != sort || len(c.Values) != len(keys) {
+		errs.add("cursor", "sort_mismatch", "cursor was issued for a different sort")
+		return nil, errs.err()
+	}
+
+	var zero M
+	after := &repository.Cursor{ID: c.ID, Values: make([]any, len(keys))}
+	for i, key := range keys {
+		target := reflect.New(reflect.TypeOf(fields[key.Field].Value(zero)))
+		if err := json.Unmarshal(c.Values[i], target.Interface()); err != nil {
+			errs.add("cursor", "invalid", "cursor is invalid")
+			return nil, errs.err()
+		}
+		after.Values[i] = target.Elem().Interface()
+	}
+	return after, nil
+}
+
+func encodeCursor[M any](last M, id uuid.UUID, sort string, keys []repository.SortKey, fields map[string]repository.Field[M]) string {
+	c := pageCursor{Sort: sort, ID: id}
+	for _, key := range keys {
+		value, _ := json.Marshal(fields[key.Field].Value(last))
+		c.Values = append(c.Values, value)
+	}
+	payload, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+func decodeCursor(cursor string) (*pageCursor, error) {
+	payload, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, err
+	}
+	var c pageCursor
+	if err := json.Unmarshal(payload, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
@@ -0,0 +1,182 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gmdb/repository"
+
+	"github.com/google/uuid"
+)
+
+// ListQuery is the raw filter, sort and page input of a list endpoint
+type ListQuery struct {
+	Page    PageRequest
+	Filters map[string]string // raw filter values keyed by query parameter
+	Sort    string            // comma-separated fields, "-" prefix for descending
+}
+
+// filterSpec maps one query parameter onto a repository field comparison.
+// User input only ever reaches the database as a bound argument.
+type filterSpec struct {
+	field string
+	op    repository.Op
+	parse func(string) (any, error)
+}
+
+// listSpec declares which filters and sort fields a resource accepts
+type listSpec[M any] struct {
+	filters map[string]filterSpec
+	sorts   []string // allowed sort fields
+	fields  map[string]repository.Field[M]
+	id      func(M) uuid.UUID
+}
+
+// options validates q against the spec and builds repository list options
+func (spec listSpec[M]) options(q ListQuery) (repository.ListOptions, error) {
+	var errs fieldErrors
+	opts := repository.ListOptions{Limit: q.Page.Limit, IncludeTotal: q.Page.IncludeTotal}
+
+	// Sort names so filters and error details come out in a stable order
+	names := make([]string, 0, len(q.Filters))
+	for name := range q.Filters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		filter, ok := spec.filters[name]
+		if !ok {
+			errs.add(name, "unknown_filter", name+" is not a supported filter; allowed: "+strings.Join(spec.filterNames(), ", "))
+			continue
+		}
+		value, err := filter.parse(q.Filters[name])
+		if err != nil {
+			errs.add(name, "invalid", name+" has an invalid value")
+			continue
+		}
+		opts.Filters = append(opts.Filters, repository.Filter{Field: filter.field, Op: filter.op, Value: value})
+	}
+
+	opts.Sort = spec.sortKeys(q.Sort, &errs)
+	if err := errs.err(); err != nil {
+		return opts, err
+	}
+
+	after, err := cursorAfter(q.Page.cursor, normalizeSort(q.Sort), opts.Sort, spec.fields)
+	if err != nil {
+		return opts, err
+	}
+	opts.After = after
+	return opts, nil
+}
+
+// listPage validates q, runs list and maps the rows into a page of responses
+func listPage[M any, R any](ctx context.Context, spec listSpec[M], q ListQuery, list func(context.Context, repository.ListOptions) (*repository.ListResult[M], error), toResponse func(M) R, failure string) (*Page[R], error) {
+	opts, err := spec.options(q)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := list(ctx, opts)
+	if err != nil {
+		return nil, internalError(failure, err)
+	}
+
+	page := &Page[R]{Items: make([]R, len(result.Items)), Limit: opts.Limit, Total: result.Total}
+	for i, row := range result.Items {
+		page.Items[i] = toResponse(row)
+	}
+	if result.HasMore {
+		last := result.Items[len(result.Items)-1]
+		page.NextCursor = encodeCursor(last, spec.id(last), normalizeSort(q.Sort), opts.Sort, spec.fields)
+	}
+	return page, nil
+}
+
+// sortKeys parses "-rating,title" into sort keys, collecting unknown fields into errs
+func (spec listSpec[M]) sortKeys(raw string, errs *fieldErrors) []repository.SortKey {
+	if raw == "" {
+		return nil
+	}
+
+	var keys []repository.SortKey
+	seen := make(map[string]bool)
+	for _, field := range strings.Split(raw, ",") {
+		field = strings.TrimSpace(field)
+		desc := strings.HasPrefix(field, "-")
+		name := strings.TrimPrefix(field, "-")
+
+		if !spec.sortable(name) || seen[name] {
+			errs.add("sort", "unknown_field", "cannot sort by "+strconv.Quote(name)+"; allowed: "+strings.Join(spec.sorts, ", "))
+			continue
+		}
+		seen[name] = true
+		keys = append(keys, repository.SortKey{Field: name, Desc: desc})
+	}
+	return keys
+}
+
+func (spec listSpec[M]) sortable(name string) bool {
+	for _, allowed := range spec.sorts {
+		if allowed == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (spec listSpec[M]) filterNames() []string {
+	names := make([]string, 0, len(spec.filters))
+	for name := range spec.filters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// normalizeSort strips whitespace so equivalent sort strings share cursors
+func normalizeSort(raw string) string {
+	return strings.ReplaceAll(raw, " ", "")
+}
+
+// Filter value parsers
+
+func parseString(v string) (any, error) {
+	return v, nil
+}
+
+func parseInt(v string) (any, error) {
+	return strconv.Atoi(v)
+}
+
+func parseFloat(v string) (any, error) {
+	return strconv.ParseFloat(v, 64)
+}
+
+func parseUUID(v string) (any, error) {
+	return uuid.Parse(v)
+}
+
+// parseDateStart turns a year or YYYY-MM-DD date into the moment it begins
+func parseDateStart(v string) (any, error) {
+	if year, err := strconv.Atoi(v); err == nil {
+		return time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC), nil
+	}
+	return time.Parse("2006-01-02", v)
+}
+
+// parseDateEnd turns a year or YYYY-MM-DD date into the moment after it ends
+func parseDateEnd(v string) (any, error) {
+	if year, err := strconv.Atoi(v); err == nil {
+		return time.Date(year+1, time.January, 1, 0, 0, 0, 0, time.UTC), nil
+	}
+	day, err := time.Parse("2006-01-02", v)
+	if err != nil {
+		return nil, err
+	}
+	return day.AddDate(0, 0, 1), nil
+}
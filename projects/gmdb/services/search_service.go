@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"gmdb/repository"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Search result bounds
+const (
+	DefaultSearchLimit = 20
+	MaxSearchLimit     = 50
+)
+
+// searchTypes is the order types are queried in when no filter is given
+var searchTypes = []string{"movie", "actor", "award"}
+
+type SearchService struct {
+	tracer trace.Tracer
+	search repository.SearchRepository
+}
+
+// NewSearchService creates a new search service instance
+func NewSearchService(search repository.SearchRepository, tracer trace.Tracer) *SearchService {
+	return &SearchService{tracer: tracer, search: search}
+}
+
+// SearchRequest represents the raw input of a search
+type SearchRequest struct {
+	Query string // websearch syntax, e.g. "dark knight" -batman
+	Types string // optional comma-separated subset of movie, actor, award
+	Limit string
+}
+
+// SearchResult is one ranked hit of any type
+type SearchResult struct {
+	Type    string    `json:"type"`
+	ID      uuid.UUID `json:"id"`
+	Title   string    `json:"title"`
+	Snippet string    `json:"snippet"`
+	Rank    float64   `json:"rank"`
+}
+
+// Search runs a ranked full-text search across movies, actors and awards
+func (s *SearchService) Search(ctx context.Context, req SearchRequest) (_ []*SearchResult, err error) {
+	ctx, end := startSpan(ctx, s.tracer, "SearchService.Search")
+	defer end(&err)
+
+	query := strings.TrimSpace(req.Query)
+	types, limit, err := s.validateSearch(query, req)
+	if err != nil {
+		return nil, err
+	}
+
+	hits, err := s.search.Search(ctx, query, types, limit)
+	if err != nil {
+		return nil, internalError("failed to search", err)
+	}
+
+	results := make([]*SearchResult, len(hits))
+	for i, hit := range hits {
+		results[i] = &SearchResult{
+			Type:    hit.Type,
+			ID:      hit.ID,
+			Title:   hit.Title,
+			Snippet: hit.Snippet,
+			Rank:    hit.Rank,
+		}
+	}
+	return results, nil
+}
+
+// Business logic validation
+func (s *SearchService) validateSearch(query string, req SearchRequest) ([]string, int, error) {
+	var errs fieldErrors
+
+	if query == "" {
+		errs.add("q", "required", "search query is required")
+	}
+
+	types := searchTypes
+	if req.Types != "" {
+		types = nil
+		for _, t := range strings.Split(req.Types, ",") {
+			t = strings.TrimSpace(t)
+			if !isSearchType(t) {
+				errs.add("type", "invalid_choice", "type must be one of movie, actor, award")
+				continue
+			}
+			types = append(types, t)
+		}
+	}
+
+	limit := DefaultSearchLimit
+	if req.Limit != "" {
+		n, err := strconv.Atoi(req.Limit)
+		if err != nil || n < 1 || n > MaxSearchLimit {
+			errs.add("limit", "out_of_range", "limit must be between 1 and "+strconv.Itoa(MaxSearchLimit))
+		}
+		limit = n
+	}
+
+	return types, limit, errs.err()
+}
+
+func isSearchType(t string) bool {
+	for _, known := range searchTypes {
+		if known == t {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,35 @@
+package services
+
+import (
+	"gmdb/auth"
+	"gmdb/repository"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Services bundles every service built on one set of repositories
+type Services struct {
+	Actors  *ActorService
+	Movies  *MovieService
+	Awards  *AwardService
+	Cast    *CastService
+	Search  *SearchService
+	Auth    *AuthService
+	APIKeys *APIKeyService
+}
+
+// New creates all services on top of repos, issuing credentials with tokens;
+// every exported method starts a span from tracer
+func New(repos *repository.Repositories, tokens *auth.Tokens, tracer trace.Tracer) *Services {
+	actors := NewActorService(repos.Actors, tracer)
+	movies := NewMovieService(repos.Movies, tracer)
+	return &Services{
+		Actors:  actors,
+		Movies:  movies,
+		Awards:  NewAwardService(repos.Awards, repos.Movies, repos.Actors, tracer),
+		Cast:    NewCastService(repos.Movies, repos.Actors, movies, actors, tracer),
+		Search:  NewSearchService(repos.Search, tracer),
+		Auth:    NewAuthService(repos.Users, repos.RefreshTokens, tokens, tracer),
+		APIKeys: NewAPIKeyService(repos.APIKeys, tracer),
+	}
+}
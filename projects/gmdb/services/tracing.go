@@ -0,0 +1,38 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// startSpan starts a span named after a service method, e.g.
+// ActorService.CreateActor. Defer the returned func with a pointer to the
+// method's error result: internal errors mark the span failed, while domain
+// errors such as not found only record their code.
+func startSpan(ctx context.Context, tracer trace.Tracer, name string) (context.Context, func(*error)) {
+	ctx, span := tracer.Start(ctx, name)
+	return ctx, func(errp *error) {
+		defer span.End()
+		if *errp == nil {
+			return
+		}
+
+		var domainErr *Error
+		if !errors.As(*errp, &domainErr) {
+			span.RecordError(*errp)
+			span.SetStatus(codes.Error, (*errp).Error())
+			return
+		}
+		span.SetAttributes(attribute.String("gmdb.error_code", domainErr.Code))
+		if errors.Is(domainErr, ErrInternal) {
+			if domainErr.Cause != nil {
+				span.RecordError(domainErr.Cause)
+			}
+			span.SetStatus(codes.Error, domainErr.Message)
+		}
+	}
+}
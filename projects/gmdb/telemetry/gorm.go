@@ -0,0 +1,68 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+
+	"gmdb/utils"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+// Keys storing a statement's span and its caller's context on the *gorm.DB instance
+const (
+	spanKey   = "telemetry:span"
+	parentKey = "telemetry:parent"
+)
+
+// InstrumentDB starts a client span for every GORM statement, as a child of
+// the span in the statement's context. SQL is recorded with placeholders,
+// never with bound values.
+func InstrumentDB(db *gorm.DB, tp trace.TracerProvider) error {
+	tracer := tp.Tracer(InstrumentationName)
+
+	return utils.RegisterGormHooks(db, "telemetry",
+		func(operation string) func(*gorm.DB) { return startSpan(tracer, operation) },
+		func(string) func(*gorm.DB) { return endSpan },
+	)
+}
+
+func startSpan(tracer trace.Tracer, operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		ctx, span := tracer.Start(db.Statement.Context, "gorm."+operation,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(semconv.DBSystemNamePostgreSQL),
+		)
+		db.InstanceSet(parentKey, db.Statement.Context)
+		db.InstanceSet(spanKey, span)
+		db.Statement.Context = ctx
+	}
+}
+
+func endSpan(db *gorm.DB) {
+	value, ok := db.InstanceGet(spanKey)
+	if !ok {
+		return
+	}
+	span := value.(trace.Span)
+	defer span.End()
+
+	// Later statements on a shared instance must not nest under this span
+	if parent, ok := db.InstanceGet(parentKey); ok {
+		db.Statement.Context = parent.(context.Context)
+	}
+
+	span.SetAttributes(
+		semconv.DBQueryText(db.Statement.SQL.String()),
+		semconv.DBCollectionName(db.Statement.Table),
+		attribute.Int64("db.rows_affected", db.Statement.RowsAffected),
+	)
+	if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		span.RecordError(db.Error)
+		span.SetStatus(codes.Error, db.Error.Error())
+	}
+}
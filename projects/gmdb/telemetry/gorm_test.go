@@ -0,0 +1,62 @@
+package telemetry
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"gmdb/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestInstrumentDB(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	// DryRun builds statements and runs every callback without a server
+	conn, err := pgx.ParseConfig("host=localhost dbname=gmdb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: stdlib.OpenDB(*conn)}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := InstrumentDB(db, tp); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "request")
+	db.WithContext(ctx).Create(&models.Movie{Title: "Heat"})
+	db.WithContext(ctx).Where("title = ?", "Heat").Find(&[]models.Movie{})
+	parent.End()
+
+	var queries []tracetest.SpanStub
+	for _, span := range exporter.GetSpans() {
+		if strings.HasPrefix(span.Name, "gorm.") {
+			queries = append(queries, span)
+		}
+	}
+	if len(queries) != 2 || queries[0].Name != "gorm.create" || queries[1].Name != "gorm.query" {
+		t.Fatalf("query spans = %v", queries)
+	}
+	for _, span := range queries {
+		if span.Parent.SpanID() != parent.SpanContext().SpanID() {
+			t.Errorf("%s is not a child of the request span", span.Name)
+		}
+		for _, attr := range span.Attributes {
+			if attr.Key == "db.query.text" && strings.Contains(attr.Value.AsString(), "Heat") {
+				t.Errorf("%s recorded a bound value: %s", span.Name, attr.Value.AsString())
+			}
+		}
+	}
+}
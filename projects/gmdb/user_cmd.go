@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"gmdb/auth"
+	"gmdb/config"
+	"gmdb/models"
+	"gmdb/repository"
+	"gmdb/services"
+	"gmdb/telemetry"
+
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+var (
+	userEmail string
+	userRole  string
+)
+
+var userCmd = &cobra.Command{
+	Use:   "user",
+	Short: "Manage API users",
+	Long:  "Creates the users that log in at /api/v1/auth/login, e.g. the first admin.",
+}
+
+var userCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a user, reading the password from stdin",
+	Long: "Creates a user with the given email and role (reader, editor or admin). " +
+		"The password is read from the first line of stdin, e.g.\n" +
+		"  gmdb user create --email ada@example.com --role admin < password.txt",
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := mustLoadConfig(config.SectionDatabase)
+
+		password, err := readPassword(os.Stdin)
+		if err != nil {
+			log.Fatal("Failed to read password:", err)
+		}
+
+		db, err := config.ConnectDB(cfg.Database)
+		if err != nil {
+			log.Fatal("Failed to connect to database:", err)
+		}
+		requireCurrentSchema(db)
+
+		repos := repository.NewGormRepositories(db)
+		svc := services.NewAuthService(repos.Users, repos.RefreshTokens, auth.NewTokens(cfg.Auth), noop.NewTracerProvider().Tracer(telemetry.InstrumentationName))
+		user, err := svc.CreateUser(context.Background(), services.CreateUserRequest{
+			Email:    userEmail,
+			Password: password,
+			Role:     models.Role(userRole),
+		})
+		if err != nil {
+			printErrorDetails(err)
+			log.Fatal("Failed to create user:", err)
+		}
+
+		fmt.Printf("Created %s %s (%s)\n", user.Role, user.Email, user.ID)
+	},
+}
+
+// readPassword reads the first line of r; prompts go to stderr on a terminal
+func readPassword(r *os.File) (string, error) {
+	if info, err := r.Stat(); err == nil && info.Mode()&os.ModeCharDevice != 0 {
+		fmt.Fprint(os.Stderr, "Password (input is echoed): ")
+	}
+	line, err := bufio.NewReader(r).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func init() {
+	userCreateCmd.Flags().StringVar(&userEmail, "email", "", "email the user logs in with")
+	userCreateCmd.Flags().StringVar(&userRole, "role", string(models.RoleReader), "reader, editor or admin")
+	_ = userCreateCmd.MarkFlagRequired("email")
+
+	userCmd.AddCommand(userCreateCmd)
+}
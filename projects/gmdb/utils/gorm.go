@@ -0,0 +1,40 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// gormOperations are the GORM callback processors RegisterGormHooks wraps, in
+// registration order
+var gormOperations = []string{"create", "query", "update", "delete", "row", "raw"}
+
+// RegisterGormHooks runs before(op) ahead of and after(op) behind every GORM
+// statement. Callbacks are named "<prefix>:before_<op>" and
+// "<prefix>:after_<op>", so each prefix can be registered once per database.
+func RegisterGormHooks(db *gorm.DB, prefix string, before, after func(operation string) func(*gorm.DB)) error {
+	// Register method values have a nameable type even though GORM's
+	// processor and callback types are unexported
+	type register func(name string, fn func(*gorm.DB)) error
+	cb := db.Callback()
+	hooks := map[string]struct{ before, after register }{
+		"create": {cb.Create().Before("gorm:create").Register, cb.Create().After("gorm:create").Register},
+		"query":  {cb.Query().Before("gorm:query").Register, cb.Query().After("gorm:query").Register},
+		"update": {cb.Update().Before("gorm:update").Register, cb.Update().After("gorm:update").Register},
+		"delete": {cb.Delete().Before("gorm:delete").Register, cb.Delete().After("gorm:delete").Register},
+		"row":    {cb.Row().Before("gorm:row").Register, cb.Row().After("gorm:row").Register},
+		"raw":    {cb.Raw().Before("gorm:raw").Register, cb.Raw().After("gorm:raw").Register},
+	}
+	for _, operation := range gormOperations {
+		hook := hooks[operation]
+		if err := errors.Join(
+			hook.before(prefix+":before_"+operation, before(operation)),
+			hook.after(prefix+":after_"+operation, after(operation)),
+		); err != nil {
+			return fmt.Errorf("failed to register %s %s callbacks: %w", operation, prefix, err)
+		}
+	}
+	return nil
+}
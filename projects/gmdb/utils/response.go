@@ -4,12 +4,42 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// Request-level error codes; domain codes come from the services package
+const (
+	CodeInvalidID       = "invalid_id"
+	CodeInvalidInput    = "invalid_input"
+	CodeNotReady        = "not_ready"
+	CodeFeatureDisabled = "feature_disabled"
+	CodeUnauthorized    = "unauthorized"
+	CodeForbidden       = "forbidden"
+	CodeRateLimited     = "rate_limited"
+	CodeInternal        = "internal_error"
+)
+
+// FieldError describes one invalid request field
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// PageMeta describes where a page of results sits in the full list
+type PageMeta struct {
+	Limit      int    `json:"limit"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
+	Total      *int64 `json:"total,omitempty"`
+}
+
 // Response represents a standard API response structure
 type Response struct {
-	Success bool        `json:"success"`
-	Message string      `json:"message,omitempty"`
-	Data    interface{} `json:"data,omitempty"`
-	Error   string      `json:"error,omitempty"`
+	Success bool         `json:"success"`
+	Message string       `json:"message,omitempty"`
+	Data    interface{}  `json:"data,omitempty"`
+	Error   string       `json:"error,omitempty"`
+	Code    string       `json:"code,omitempty"`
+	Details []FieldError `json:"details,omitempty"`
+	Meta    *PageMeta    `json:"meta,omitempty"`
 }
 
 // SuccessResponse returns a successful response
@@ -21,10 +51,31 @@ func SuccessResponse(c *gin.Context, status int, message string, data interface{
 	})
 }
 
-// ErrorResponse returns an error response
-func ErrorResponse(c *gin.Context, status int, message string) {
+// PageResponse returns a successful response for one page of a list
+func PageResponse(c *gin.Context, status int, message string, data interface{}, meta *PageMeta) {
+	c.JSON(status, Response{
+		Success: true,
+		Message: message,
+		Data:    data,
+		Meta:    meta,
+	})
+}
+
+// ErrorResponse returns an error response with a machine-readable code
+func ErrorResponse(c *gin.Context, status int, code string, message string) {
+	c.JSON(status, Response{
+		Success: false,
+		Error:   message,
+		Code:    code,
+	})
+}
+
+// ValidationErrorResponse returns an error response listing every invalid field
+func ValidationErrorResponse(c *gin.Context, status int, code string, message string, details []FieldError) {
 	c.JSON(status, Response{
 		Success: false,
 		Error:   message,
+		Code:    code,
+		Details: details,
 	})
 }
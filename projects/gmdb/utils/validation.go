@@ -1,7 +1,9 @@
 package utils
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"regexp"
 
 	"github.com/google/uuid"
@@ -31,3 +33,19 @@ func ValidateNotEmpty(value string, fieldName string) error {
 	}
 	return nil
 }
+
+// BindingErrors translates a JSON decoding error into field errors. Required
+// fields and ranges are checked by the services, so only type mismatches are
+// tied to a field; it returns nil for anything else (e.g. malformed JSON).
+func BindingErrors(err error) []FieldError {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return []FieldError{{
+			Field:   typeErr.Field,
+			Code:    "invalid_type",
+			Message: fmt.Sprintf("%s must be of type %s", typeErr.Field, typeErr.Type),
+		}}
+	}
+
+	return nil
+}